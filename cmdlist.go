@@ -4,15 +4,14 @@
 package fizzgui
 
 import (
+	"math"
+
 	mgl "github.com/go-gl/mathgl/mgl32"
 	graphics "github.com/tbogdala/fizzle/graphicsprovider"
 )
 
 var defaultTextureSampler graphics.Texture = 1
 
-//cmdLists used to the render
-var zcmds map[uint8][]*cmdList
-
 // cmdList will hold all of the information required for one draw call in the user interface.
 type cmdList struct {
 	comboBuffer  []float32 // vbo combo floats
@@ -21,6 +20,7 @@ type cmdList struct {
 	indexTracker uint32    // the offset for the next set of indexes when adding new faces
 
 	texture graphics.Texture
+	clip    Rect // scissor rect in display coordinates
 }
 
 // NewCmdList creates a new command list for rendering.
@@ -34,30 +34,74 @@ func newCmdList() *cmdList {
 	return cmd
 }
 
-//GetFirstCmd create new cmd and insert in to first element
+//GetFirstCmd create new cmd in the default gui and insert in to first element
 func GetFirstCmd(z uint8) *cmdList {
-	if _, ok := zcmds[z]; !ok {
-		zcmds[z] = []*cmdList{}
+	return defaultGUI.GetFirstCmd(z)
+}
+
+//GetFirstCmd create new cmd and insert in to first element
+func (g *GUI) GetFirstCmd(z uint8) *cmdList {
+	if _, ok := g.zcmds[z]; !ok {
+		g.zcmds[z] = []*cmdList{}
 	}
 
 	prepandCmd := newCmdList()
-	zcmds[z] = append([]*cmdList{prepandCmd}, zcmds[z]...)
+	prepandCmd.clip = g.clip()
+	g.zcmds[z] = append([]*cmdList{prepandCmd}, g.zcmds[z]...)
 
-	return zcmds[z][0]
+	return g.zcmds[z][0]
 }
 
-//GetLastCmd will return the last non-custom cmdList
+//GetLastCmd will return the last non-custom cmdList of the default gui
 func GetLastCmd(z uint8) *cmdList {
-	if _, ok := zcmds[z]; !ok {
-		zcmds[z] = []*cmdList{}
+	return defaultGUI.GetLastCmd(z)
+}
+
+//GetLastCmd will return the last non-custom cmdList
+func (g *GUI) GetLastCmd(z uint8) *cmdList {
+	if _, ok := g.zcmds[z]; !ok {
+		g.zcmds[z] = []*cmdList{}
 	}
 
 	appendCmd := newCmdList()
-	zcmds[z] = append(zcmds[z], appendCmd)
+	appendCmd.clip = g.clip()
+	g.zcmds[z] = append(g.zcmds[z], appendCmd)
 
 	return appendCmd
 }
 
+// drawText draws the text at the top left position over everything drawn before in the z layer.
+func (g *GUI) drawText(font *Font, pos mgl.Vec2, color mgl.Vec4, text string, z uint8) {
+	rt := font.CreateText(pos, color, text)
+	cmd := g.GetLastCmd(z)
+	cmd.texture = font.Texture
+	cmd.AddFaces(rt.ComboBuffer, rt.IndexBuffer, rt.Faces)
+}
+
+// drawTextCentered draws the text in the middle of the rect.
+func (g *GUI) drawTextCentered(font *Font, r Rect, color mgl.Vec4, text string, z uint8) {
+	w, h, _ := font.GetRenderSize(text)
+	g.drawText(font, mgl.Vec2{r.TLX + r.W/2 - w/2, r.TLY - r.H/2 + h/2}, color, text, z)
+}
+
+//pushClip limits drawing of the next cmds by the rect intersected with the current clip
+func (g *GUI) pushClip(r Rect) {
+	g.clips = append(g.clips, r.Intersect(g.clip()))
+}
+
+//popClip restores the clip rect used before the last pushClip
+func (g *GUI) popClip() {
+	g.clips = g.clips[:len(g.clips)-1]
+}
+
+//clip returns the current clip rect, it is the whole window if nothing is pushed
+func (g *GUI) clip() Rect {
+	if len(g.clips) == 0 {
+		return g.wndLayout.GetContentRect()
+	}
+	return g.clips[len(g.clips)-1]
+}
+
 // AddFaces takes the raw vertex attribute data in a float slice as well as the
 // element indexes and adds it to the internal buffers for rendering.
 func (cmd *cmdList) AddFaces(comboFloats []float32, indexInts []uint32, faceCount uint32) {
@@ -150,3 +194,39 @@ func (cmd *cmdList) DrawFilledRect(r Rect, color mgl.Vec4, tex graphics.Texture,
 
 	cmd.AddFaces(comboBuffer, indexBuffer, 2)
 }
+
+// DrawFilledTriangle draws the triangle with a solid color.
+func (cmd *cmdList) DrawFilledTriangle(a, b, c mgl.Vec2, color mgl.Vec4) {
+	cmd.texture = defaultTextureSampler
+
+	comboBuffer := make([]float32, 0, 3*vboStride)
+	for _, v := range [3]mgl.Vec2{a, b, c} {
+		comboBuffer = append(comboBuffer, v[0], v[1], whitePixelUv[0], whitePixelUv[1], float32(defaultTextureSampler))
+		comboBuffer = append(comboBuffer, color[:]...)
+	}
+
+	cmd.AddFaces(comboBuffer, []uint32{0, 1, 2}, 1)
+}
+
+// DrawFilledCircle draws the circle with a solid color as a fan of triangles.
+func (cmd *cmdList) DrawFilledCircle(center mgl.Vec2, radius float32, color mgl.Vec4) {
+	const segments = 24
+	cmd.texture = defaultTextureSampler
+
+	comboBuffer := make([]float32, 0, (segments+1)*vboStride)
+	indexBuffer := make([]uint32, 0, segments*3)
+
+	comboBuffer = append(comboBuffer, center[0], center[1], whitePixelUv[0], whitePixelUv[1], float32(defaultTextureSampler))
+	comboBuffer = append(comboBuffer, color[:]...)
+	for i := 0; i < segments; i++ {
+		a := 2 * math.Pi * float64(i) / segments
+		x := center[0] + radius*float32(math.Cos(a))
+		y := center[1] + radius*float32(math.Sin(a))
+		comboBuffer = append(comboBuffer, x, y, whitePixelUv[0], whitePixelUv[1], float32(defaultTextureSampler))
+		comboBuffer = append(comboBuffer, color[:]...)
+
+		indexBuffer = append(indexBuffer, 0, uint32(i+1), uint32((i+1)%segments+1))
+	}
+
+	cmd.AddFaces(comboBuffer, indexBuffer, segments)
+}
@@ -6,60 +6,228 @@ type Container struct {
 	Hidden           bool
 	AutoAdjustHeight bool
 
-	//not yet ready
+	//IsScrollable allows to scroll the content by the mouse wheel, page up/down and ScrollTo
+	IsScrollable bool
+	//ShowScrollBar draws scrollbars when the content does not fit into the container
 	ShowScrollBar  bool
-	IsScrollable   bool
 	ScrollBarWidth float32
-	ScrollOffset   float32
+	ScrollOffset   float32 //vertical, 0 is the top of the content
+	ScrollOffsetX  float32 //horizontal, 0 is the left side of the content
+
+	ScrollBarStyle        Style
+	ScrollThumbStyle      Style
+	ScrollThumbStyleHover Style
+
+	//Window mode draws the title bar, see NewWindow
+	Window      bool
+	Title       string
+	Movable     bool //drag by the title bar
+	Resizable   bool //drag by the edges and corners
+	Closable    bool //close button hides the container
+	Collapsible bool //collapse button leaves only the title bar
+	Collapsed   bool
+	OnClose     func(c *Container)
+
+	TitleBarStyle      Style
+	TitleBtnStyleHover Style
 
 	FontName string
 	Style    Style
 	Layout   *Layout
 
+	//Zorder places the container above the ones with lower Zorder,
+	//containers with the same Zorder are stacked in order of the last click
 	Zorder uint8
+	//PassThrough containers do not block the mouse for the containers below them
+	PassThrough bool
 
 	Widgets []*Widget
+
+	gui   *GUI
+	zcmds map[uint8][]*cmdList //draw commands of the current frame
+
+	//nested container is constructed by its panel widget in the parent container
+	parent *Container
+	panel  *Widget
+
+	//size of the content measured in the last frame
+	contentW float32
+	contentH float32
+
+	//scrollbar which thumb is dragged by the mouse
+	dragBar        *scrollBar
+	dragStart      float32
+	dragStartValue float32
+
+	//window moving or resizing, mouse and layout at the start of drag
+	winDrag            uint8
+	winDragX, winDragY float32
+	winDragRect        Rect
+
+	//popup container shown by the widget of other container, onClose hides it
+	//in the frame the owner is not constructed in
+	owner   *Widget
+	onClose func()
 }
 
-//NewContainer creates new container for widgets
+//NewContainer creates new container for widgets in the default gui
 //x,y,w,h is string size ex: "80%", "200px"...
 func NewContainer(id string, x, y, w, h string) *Container {
-	c := &Container{
+	return defaultGUI.NewContainer(id, x, y, w, h)
+}
+
+//NewContainer creates new container for widgets
+//x,y,w,h is string size ex: "80%", "200px"...
+func (g *GUI) NewContainer(id string, x, y, w, h string) *Container {
+	c := newContainer(g, id, NewLayout(x, y, w, h, g.wndLayout))
+
+	g.containers = append(g.containers, c)
+	c.Layout.Update()
+
+	return c
+}
+
+//NewContainer creates container nested in this one, it takes part in the flow of widgets
+//like a panel, fixed position is used if x and y are set, empty h fits the height to the content
+func (c *Container) NewContainer(id string, x, y, w, h string) *Container {
+	wgt := &Widget{
+		ID:        id,
+		Container: c,
+		Layout:    NewLayout(x, y, w, h, c.Layout),
+	}
+
+	if x != "" && y != "" {
+		wgt.Layout.PositionFixed = true
+	}
+
+	child := newContainer(c.gui, id, wgt.Layout)
+	child.FontName = c.FontName
+	child.parent = c
+	child.panel = wgt
+
+	wgt.ConstructorData = child
+	wgt.Constructor = child.panelConstructor
+
+	c.addWidget(wgt)
+	return child
+}
+
+func newContainer(g *GUI, id string, layout *Layout) *Container {
+	return &Container{
+		gui:            g,
 		ID:             id,
-		Layout:         NewLayout(x, y, w, h, wndLayout),
+		Layout:         layout,
 		ScrollBarWidth: 10,
 		Style:          DefaultContainerStyle,
 		FontName:       "Default",
+
+		ScrollBarStyle:        DefaultScrollBarStyle,
+		ScrollThumbStyle:      DefaultScrollThumbStyle,
+		ScrollThumbStyleHover: DefaultScrollThumbStyleHover,
+
+		TitleBarStyle:      DefaultTitleBarStyle,
+		TitleBtnStyleHover: DefaultTitleBtnStyleHover,
 	}
+}
 
-	containers = append(containers, c)
-	c.Layout.Update()
+//panelConstructor constructs the nested container in place of its panel widget,
+//the layout is already updated and placed by the cursor of the parent
+func (c *Container) panelConstructor() (style Style) {
+	c.panel.Hidden = c.Hidden
+	if c.Hidden {
+		return
+	}
+
+	c.Zorder = c.panel.Z
+
+	//the widgets get all room of the parent, the height is fitted to them in construct
+	if c.fitHeight() {
+		c.Layout.H = c.parent.Layout.GetContentRect().H
+	}
 
+	c.construct()
+	return
+}
+
+//fitHeight reports whether the height of the nested container follows its content
+func (c *Container) fitHeight() bool {
+	return c.parent != nil && c.Layout.h.value == 0
+}
+
+//root returns the top level container of the nested one
+func (c *Container) root() *Container {
+	for c.parent != nil {
+		c = c.parent
+	}
 	return c
 }
 
+//hitTest returns the deepest container and the top widget of it under the point
+func (c *Container) hitTest(x, y float32) (hc *Container, hw *Widget) {
+	hc = c
+
+	//widgets scrolled out of the content are not visible
+	if !c.Layout.GetContentRect().ContainsPoint(x, y) {
+		return
+	}
+
+	for _, wgt := range c.Widgets {
+		if wgt.Hidden || !wgt.Layout.ContainsPoint(x, y) {
+			continue
+		}
+		if hw == nil || wgt.Z > hw.Z {
+			hw = wgt
+		}
+	}
+
+	if hw != nil {
+		if child, ok := hw.ConstructorData.(*Container); ok && child.panel == hw {
+			return child.hitTest(x, y)
+		}
+	}
+
+	return
+}
+
 func (c *Container) addWidget(wgt *Widget) {
 	c.Widgets = append(c.Widgets, wgt)
 }
 
-//Close function remove this window from window slice
+//Close function remove this window from window slice, nested container is removed from its parent
 func (c *Container) Close() {
-	DelContainer(c)
+	if c.panel != nil {
+		c.panel.Destroy()
+		return
+	}
+	c.gui.DelContainer(c)
 }
 
 // construct should be call each frame
 func (c *Container) construct() {
-	c.Layout.Update()
+	if c.parent == nil {
+		c.Layout.Update()
+	}
 	// Keys.GetKeys()
 
 	// empty out the cmd list and start a new command
 	// c.zcmds = make(map[uint8][]*cmdList)
 
-	if c.IsScrollable && HoverContainer == c {
-		c.ScrollOffset -= Mouse.ScrollDelta //c.Owner.GetScrollWheelDelta(true)
-		if c.ScrollOffset < 0.0 {
-			c.ScrollOffset = 0.0
+	c.Layout.reserved = Offset{}
+	if c.Window {
+		c.updateWindow()
+	}
+
+	if c.Collapsed {
+		if !c.Hidden {
+			c.draw(c.Layout.Y)
+			c.drawTitleBar()
 		}
+		return
+	}
+
+	vbar, hbar := c.scrollBars()
+	if c.IsScrollable {
+		c.scroll(vbar, hbar)
 	}
 
 	for i := 0; i < len(c.Widgets); i++ {
@@ -72,16 +240,280 @@ func (c *Container) construct() {
 
 	//cursor initialize with content point of left X and Top Y
 	cursor := c.newCursor()
+	c.gui.pushClip(c.Layout.GetContentRect())
 	for _, wgt := range c.Widgets {
 		w, h := wgt.draw(cursor)
 		cursor.add(w, h)
 	}
+	c.gui.popClip()
+
+	c.measureContent(cursor.rect)
+
+	if c.fitHeight() {
+		l := c.Layout
+		l.H = c.contentH + l.Margin.T + l.Margin.B + l.Padding.T + l.Padding.B + l.reserved.T + l.reserved.B
+	}
 
 	if !c.Hidden {
 		c.draw(cursor.Y)
+		c.drawScrollBar(vbar)
+		c.drawScrollBar(hbar)
+		if c.Window {
+			c.drawTitleBar()
+		}
 	}
 }
 
+//measureContent stores the size of the content drawn from the top left corner of the rect
+func (c *Container) measureContent(r Rect) {
+	c.contentW, c.contentH = 0, 0
+	for _, wgt := range c.Widgets {
+		if wgt.Hidden || wgt.Layout.parent != c.Layout {
+			continue
+		}
+
+		//flowing widgets are wrapped to the content width, so only fixed ones widen it
+		l := wgt.Layout
+		if w := l.X + l.W - r.TLX; l.PositionFixed && w > c.contentW {
+			c.contentW = w
+		}
+		if h := r.TLY - (l.Y - l.H); h > c.contentH {
+			c.contentH = h
+		}
+	}
+}
+
+//maxScroll returns the greatest offsets at which the end of the content is visible
+func (c *Container) maxScroll() (x, y float32) {
+	r := c.Layout.GetContentRect()
+	if c.contentW > r.W {
+		x = c.contentW - r.W
+	}
+	if c.contentH > r.H {
+		y = c.contentH - r.H
+	}
+	return
+}
+
+func (c *Container) clampScroll() {
+	maxX, maxY := c.maxScroll()
+	c.ScrollOffsetX = clampFloat(c.ScrollOffsetX, 0, maxX)
+	c.ScrollOffset = clampFloat(c.ScrollOffset, 0, maxY)
+}
+
+//scroll applies the mouse wheel, page keys and dragging of the scrollbars
+func (c *Container) scroll(vbar, hbar *scrollBar) {
+	gui := c.gui
+	mouse := gui.Mouse
+	view := c.Layout.GetContentRect()
+
+	if gui.scrollTarget() == c {
+		if gui.HoverWidget == nil || !gui.HoverWidget.scrollable {
+			c.ScrollOffset -= mouse.ScrollDelta
+			c.ScrollOffsetX -= mouse.ScrollDeltaX
+		}
+
+		if gui.ActiveWidget == nil || gui.ActiveWidget.Container != c {
+			for _, k := range gui.Keys.GetKeys() {
+				switch k.KeyCode {
+				case KeyPageUp:
+					c.ScrollOffset -= view.H
+				case KeyPageDown:
+					c.ScrollOffset += view.H
+				}
+			}
+		}
+	}
+
+	if mouse.GetButtonAction(0) != MouseDown {
+		c.dragBar = nil
+	}
+
+	for _, bar := range []*scrollBar{vbar, hbar} {
+		if bar == nil {
+			continue
+		}
+
+		if c.dragBar == nil && c.winDrag == 0 && gui.HoverContainer == c && mouse.JustPressed(0) && bar.track.ContainsPoint(mouse.X, mouse.Y) {
+			if bar.thumb.ContainsPoint(mouse.X, mouse.Y) {
+				c.dragBar = bar
+				c.dragStart = bar.mousePos(mouse.X, mouse.Y)
+				c.dragStartValue = *bar.value
+			} else if bar.mousePos(mouse.X, mouse.Y) < bar.thumbPos() {
+				*bar.value -= bar.page
+			} else {
+				*bar.value += bar.page
+			}
+		}
+
+		if c.dragBar != nil && c.dragBar.vertical == bar.vertical {
+			c.dragBar = bar
+			if free := bar.length - bar.thumbLength; free > 0 {
+				delta := bar.mousePos(mouse.X, mouse.Y) - c.dragStart
+				*bar.value = c.dragStartValue + delta*bar.max/free
+			}
+		}
+	}
+
+	c.clampScroll()
+	if vbar != nil {
+		vbar.updateThumb()
+	}
+	if hbar != nil {
+		hbar.updateThumb()
+	}
+}
+
+//ScrollTo changes scroll offsets so the widget becomes visible,
+//the position of the widget is taken from the last constructed frame
+func (c *Container) ScrollTo(wgt *Widget) {
+	if wgt.Container != c {
+		return
+	}
+
+	r := c.Layout.GetContentRect()
+	l := wgt.Layout
+
+	//widget edges relative to the top left corner of the content
+	top := r.TLY + c.ScrollOffset - l.Y
+	bottom := top + l.H
+	left := l.X - r.TLX + c.ScrollOffsetX
+	right := left + l.W
+
+	if bottom > c.ScrollOffset+r.H {
+		c.ScrollOffset = bottom - r.H
+	}
+	if top < c.ScrollOffset {
+		c.ScrollOffset = top
+	}
+	if right > c.ScrollOffsetX+r.W {
+		c.ScrollOffsetX = right - r.W
+	}
+	if left < c.ScrollOffsetX {
+		c.ScrollOffsetX = left
+	}
+
+	c.clampScroll()
+}
+
+//scrollBar contains the geometry of one scrollbar of the container
+type scrollBar struct {
+	vertical bool
+
+	track       Rect
+	thumb       Rect
+	length      float32 //length of the track
+	thumbLength float32
+
+	value *float32 //scroll offset changed by this bar
+	max   float32
+	page  float32
+}
+
+//scrollBars reserves space for the scrollbars needed by the content size
+//of the last frame and returns them, nil is returned for the hidden bar
+func (c *Container) scrollBars() (vbar, hbar *scrollBar) {
+	l := c.Layout
+	if !c.IsScrollable || !c.ShowScrollBar {
+		return
+	}
+
+	//a bar takes space from the other direction, so it can make the second bar needed,
+	//the empty content needs no bar even if the container is smaller than the bar
+	r := l.GetContentRect()
+	needV := c.contentH > 0 && c.contentH > r.H
+	needH := c.contentW > 0 && c.contentW > r.W
+	if needV && !needH {
+		needH = c.contentW > 0 && c.contentW > r.W-c.ScrollBarWidth
+	}
+	if needH && !needV {
+		needV = c.contentH > 0 && c.contentH > r.H-c.ScrollBarWidth
+	}
+
+	if needV {
+		l.reserved.R = c.ScrollBarWidth
+	}
+	if needH {
+		l.reserved.B = c.ScrollBarWidth
+	}
+
+	r = l.GetContentRect()
+	maxX, maxY := c.maxScroll()
+
+	if needV {
+		track := r
+		track.TLX = r.BRX
+		track.BRX = r.BRX + c.ScrollBarWidth
+		track.W = c.ScrollBarWidth
+
+		vbar = &scrollBar{vertical: true, track: track, length: track.H, value: &c.ScrollOffset, max: maxY, page: r.H}
+		vbar.thumbLength = track.H * r.H / c.contentH
+		vbar.updateThumb()
+	}
+
+	if needH {
+		track := r
+		track.TLY = r.BRY
+		track.BRY = r.BRY - c.ScrollBarWidth
+		track.H = c.ScrollBarWidth
+
+		hbar = &scrollBar{track: track, length: track.W, value: &c.ScrollOffsetX, max: maxX, page: r.W}
+		hbar.thumbLength = track.W * r.W / c.contentW
+		hbar.updateThumb()
+	}
+
+	return
+}
+
+//mousePos returns the distance from the start of the track to the mouse
+func (bar *scrollBar) mousePos(x, y float32) float32 {
+	if bar.vertical {
+		return bar.track.TLY - y
+	}
+	return x - bar.track.TLX
+}
+
+//thumbPos returns the distance from the start of the track to the thumb
+func (bar *scrollBar) thumbPos() float32 {
+	if bar.max <= 0 {
+		return 0
+	}
+	return (bar.length - bar.thumbLength) * *bar.value / bar.max
+}
+
+func (bar *scrollBar) updateThumb() {
+	const minThumbLength = 16
+
+	bar.thumbLength = clampFloat(bar.thumbLength, minThumbLength, bar.length)
+
+	pos := bar.thumbPos()
+	bar.thumb = bar.track
+	if bar.vertical {
+		bar.thumb.TLY = bar.track.TLY - pos
+		bar.thumb.BRY = bar.thumb.TLY - bar.thumbLength
+		bar.thumb.H = bar.thumbLength
+	} else {
+		bar.thumb.TLX = bar.track.TLX + pos
+		bar.thumb.BRX = bar.thumb.TLX + bar.thumbLength
+		bar.thumb.W = bar.thumbLength
+	}
+}
+
+func (c *Container) drawScrollBar(bar *scrollBar) {
+	if bar == nil {
+		return
+	}
+
+	style := c.ScrollThumbStyle
+	if mouse := c.gui.Mouse; c.dragBar == bar || (c.gui.HoverContainer == c && bar.thumb.ContainsPoint(mouse.X, mouse.Y)) {
+		style = c.ScrollThumbStyleHover
+	}
+
+	cmd := c.gui.GetLastCmd(c.Zorder)
+	cmd.DrawFilledRect(bar.track, c.ScrollBarStyle.BackgroundColor, defaultTextureSampler, whitePixelUv)
+	cmd.DrawFilledRect(bar.thumb, style.BackgroundColor, defaultTextureSampler, whitePixelUv)
+}
+
 //Cursor provide point to widgets position
 type Cursor struct {
 	Layout *Layout
@@ -89,15 +521,24 @@ type Cursor struct {
 	X         float32
 	Y         float32
 	rowHeight float32
+
+	rect Rect //content rect moved by the scroll offsets
 }
 
 func (c *Container) newCursor() (cursor *Cursor) {
 	r := c.Layout.GetContentRect()
 
+	//content is moved by the scroll offsets
+	r.TLX -= c.ScrollOffsetX
+	r.BRX -= c.ScrollOffsetX
+	r.TLY += c.ScrollOffset
+	r.BRY += c.ScrollOffset
+
 	cursor = &Cursor{
 		Layout: c.Layout,
 		X:      r.TLX,
 		Y:      r.TLY,
+		rect:   r,
 	}
 
 	return
@@ -109,25 +550,20 @@ func (cursor *Cursor) add(w, h float32) {
 	}
 
 	cursor.X += w
-	r := cursor.Layout.GetContentRect()
 
-	if cursor.X >= r.BRX {
-		cursor.X = r.TLX
-		cursor.Y -= cursor.rowHeight
-		cursor.rowHeight = 0
+	if cursor.X >= cursor.rect.BRX {
+		cursor.NextRow()
 	}
 }
 
 func (cursor *Cursor) NextRow() {
-	r := cursor.Layout.GetContentRect()
-
-	cursor.X = r.TLX
+	cursor.X = cursor.rect.TLX
 	cursor.Y -= cursor.rowHeight
 	cursor.rowHeight = 0
 }
 
 func (c *Container) draw(bry float32) {
-	cmd := GetFirstCmd(c.Zorder)
+	cmd := c.gui.GetFirstCmd(c.Zorder)
 
 	r := c.Layout.GetBackgroundRect()
 	if c.AutoAdjustHeight {
@@ -0,0 +1,92 @@
+package fizzgui
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScrollBarsEmptyContent(t *testing.T) {
+	g, _, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "200px", "8px")
+	c.IsScrollable = true
+	c.ShowScrollBar = true
+	c.Layout.Padding = Offset{}
+	c.Layout.Update()
+
+	//the wide content without the height makes the horizontal bar only
+	c.contentW, c.contentH = 500, 0
+	vbar, hbar := c.scrollBars()
+	if vbar != nil {
+		t.Fatal("vertical bar for the empty content")
+	}
+	if hbar == nil || math.IsNaN(float64(hbar.thumbLength)) || math.IsInf(float64(hbar.thumbLength), 0) {
+		t.Fatal("horizontal bar thumb is not valid", hbar)
+	}
+}
+
+func TestClipToContainer(t *testing.T) {
+	g, _, r := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "120px", "60px")
+	c.NewText("a very long text overflowing the container")
+	g.Construct()
+	g.Construct()
+
+	//nothing is drawn right of the container
+	img := r.Image()
+	bg := c.Layout.GetBackgroundRect()
+	for y := 0; y < img.Rect.Dy(); y++ {
+		for x := int(bg.BRX) + 1; x < img.Rect.Dx(); x++ {
+			if p := img.RGBAAt(x, y); p != r.ClearColor {
+				t.Fatalf("pixel %d,%d outside of the container is drawn: %v", x, y, p)
+			}
+		}
+	}
+}
+
+func TestClickRaise(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	a := g.NewContainer("a", "10px", "10px", "200px", "150px")
+	b := g.NewContainer("b", "60px", "60px", "200px", "150px")
+	g.Construct()
+
+	//the overlapped area belongs to the last created container
+	moveCursor(g, host, 150, 200)
+	if g.HoverContainer != b {
+		t.Fatal("overlapped area is not hovered by the top container", g.HoverContainer.ID)
+	}
+
+	//the click on the visible part of the lower container raises it
+	moveCursor(g, host, 30, 280)
+	host.PressButton(0)
+	g.Construct()
+	host.ReleaseButton(0)
+	g.Construct()
+	if g.containers[len(g.containers)-1] != a {
+		t.Fatal("clicked container is not raised")
+	}
+
+	moveCursor(g, host, 150, 200)
+	if g.HoverContainer != a {
+		t.Fatal("overlapped area is not hovered by the raised container", g.HoverContainer.ID)
+	}
+}
+
+func TestNestedContainerClick(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	p := g.NewContainer("p", "10px", "10px", "380px", "280px")
+	p.NewText("parent")
+	p.NewRow()
+	nested := p.NewContainer("nested", "", "", "50%", "")
+
+	clicked := 0
+	btn := nested.NewButton("button", func(*Widget) { clicked++ })
+	p.NewText("after the nested container")
+
+	click(g, host, btn)
+	if g.HoverContainer != nested || g.HoverWidget != btn {
+		t.Fatal("button in the nested container is not hovered", g.HoverContainer.ID, g.HoverWidget)
+	}
+	if clicked != 1 {
+		t.Fatal("button in the nested container is clicked", clicked)
+	}
+}
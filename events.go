@@ -0,0 +1,103 @@
+package fizzgui
+
+//mouseButtons is the number of the mouse buttons delivered to the widget callbacks: left, right and middle
+const mouseButtons = 3
+
+//MouseEvent contains details of the mouse event of the widget
+type MouseEvent struct {
+	Widget *Widget
+
+	Button     int     //0 is the left button, 1 is the right one, 2 is the middle one
+	X, Y       float32 //position relative to the top left corner of the widget, Y goes down
+	Mods       ModifierKey
+	ClickCount int //1 for the single click, 2 for the double click and so on
+
+	ScrollX, ScrollY float32 //scroll of the frame for OnScroll
+}
+
+//MouseCallback receives the mouse events of the widget
+type MouseCallback func(e MouseEvent)
+
+//widgetMouse contains the widgets the events are tracked for between frames
+type widgetMouse struct {
+	hovered *Widget
+	pressed [mouseButtons]*Widget //widget under the press, it gets the release and the click
+}
+
+//dispatchMouse calls the mouse callbacks of the widgets: hover enter and leave, press, release, click and scroll,
+//the release is delivered to the pressed widget, the click only if the mouse is released over it
+func (g *GUI) dispatchMouse() {
+	m := g.Mouse
+	wm := &g.widgetMouse
+
+	if hw := g.HoverWidget; hw != wm.hovered {
+		prev := wm.hovered
+		wm.hovered = hw
+
+		if prev != nil && prev.OnHoverLeave != nil {
+			prev.OnHoverLeave(g.mouseEvent(prev, -1))
+		}
+		if hw != nil && hw.OnHoverEnter != nil {
+			hw.OnHoverEnter(g.mouseEvent(hw, -1))
+		}
+	}
+
+	for button := 0; button < mouseButtons; button++ {
+		switch action := m.GetButtonAction(button); {
+		case m.JustPressed(button):
+			wm.pressed[button] = g.HoverWidget
+			if wgt := g.HoverWidget; wgt != nil && wgt.OnMouseDown != nil {
+				wgt.OnMouseDown(g.mouseEvent(wgt, button))
+			}
+
+		case action == MouseClick || action == MouseDoubleClick:
+			wgt := wm.pressed[button]
+			wm.pressed[button] = nil
+			if wgt == nil {
+				continue
+			}
+
+			e := g.mouseEvent(wgt, button)
+			if wgt.OnMouseUp != nil {
+				wgt.OnMouseUp(e)
+			}
+			if wgt != g.HoverWidget {
+				continue
+			}
+
+			if wgt.OnClick != nil {
+				wgt.OnClick(e)
+			}
+			if button == 1 && wgt.OnRightClick != nil {
+				wgt.OnRightClick(e)
+			}
+			if action == MouseDoubleClick && wgt.OnDoubleClick != nil {
+				wgt.OnDoubleClick(e)
+			}
+		}
+	}
+
+	if wgt := g.HoverWidget; wgt != nil && wgt.OnScroll != nil && (m.ScrollDelta != 0 || m.ScrollDeltaX != 0) {
+		e := g.mouseEvent(wgt, -1)
+		e.ScrollX, e.ScrollY = m.ScrollDeltaX, m.ScrollDelta
+		wgt.OnScroll(e)
+	}
+}
+
+//mouseEvent returns the event of the widget at the current mouse position, button is -1 for the events without it
+func (g *GUI) mouseEvent(wgt *Widget, button int) MouseEvent {
+	r := wgt.Layout.GetBackgroundRect()
+
+	e := MouseEvent{
+		Widget: wgt,
+		Button: button,
+		X:      g.Mouse.X - r.TLX,
+		Y:      r.TLY - g.Mouse.Y,
+		Mods:   g.Keys.GetHeldMods(),
+	}
+	if button >= 0 {
+		e.ClickCount = g.Mouse.GetClickCount(button)
+	}
+
+	return e
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/tbogdala/fizzle/graphicsprovider/opengl"
 
 	"github.com/sg3des/fizzgui"
+	"github.com/sg3des/fizzgui/glfwhost"
 )
 
 var (
@@ -33,10 +34,13 @@ func main() {
 
 	window, gfx = initGraphics("fizzgui-example", 800, 600)
 
-	if err := fizzgui.Init(window, gfx); err != nil {
+	if err := glfwhost.Init(window, gfx); err != nil {
 		log.Fatalln("Failed initialize fizzgui, reason:", err)
 	}
 
+	//escape in the focused input does not reach keyCallback closing the window
+	fizzgui.Default().StopPropagation = true
+
 	//load a default font
 	_, err := fizzgui.NewFont("Default", "../assets/Roboto-Bold.ttf", 16, fizzgui.FontGlyphs)
 	if err != nil {
@@ -93,8 +97,8 @@ func widgets() {
 	left.NewInput("input0", &inp0, wgtCallback)
 	left.NewInput("input1", &inp1, wgtCallback)
 
-	left.NewCheckbox(&ok, wgtCallback)
-	left.NewText("checkbox")
+	left.NewCheckboxLabel(&ok, "checkbox", wgtCallback)
+	left.NewToggle(&ok, "toggle", wgtCallback)
 
 	left.NewRow().Layout.SetHeight("20px")
 
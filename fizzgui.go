@@ -5,235 +5,340 @@ package fizzgui
 
 import (
 	"log"
+	"sort"
+	"sync"
 	"time"
 
-	"github.com/go-gl/glfw/v3.2/glfw"
 	mgl "github.com/go-gl/mathgl/mgl32"
 	graphics "github.com/tbogdala/fizzle/graphicsprovider"
-	"github.com/tbogdala/fizzle/renderer/forward"
 )
 
 var (
-	window *glfw.Window
-	gfx    graphics.GraphicsProvider
+	whitePixelUv = mgl.Vec4{1, 1, 1, 1}
+	imagePixelUv = mgl.Vec4{0, 0, 1, 1}
+
+	defaultGUI *GUI
 
-	mainShader graphics.Program
+	//default styles are made from the colors once, so the changes of the app are kept by the next GUIs
+	defaultStylesOnce sync.Once
+)
+
+// GUI holds all of the state of one user interface: the host it works with,
+// the renderer, loaded fonts, containers and the widgets the user
+// interacts with. Several GUIs may live side by side in one process.
+type GUI struct {
+	host     Host
+	renderer Renderer
 
-	comboBuffer []float32
-	indexBuffer []uint32
-	comboVBO    graphics.Buffer
-	indexVBO    graphics.Buffer
-	vao         uint32
-	faceCount   uint32
+	//cmdLists used to the render, it is the layer of the constructed container
+	zcmds map[uint8][]*cmdList
+	clips []Rect
 
 	frameTime time.Time
 	dt        float32
-
-	whitePixelUv = mgl.Vec4{1, 1, 1, 1}
-	imagePixelUv = mgl.Vec4{0, 0, 1, 1}
+	frame     uint64 //number of the constructed frames
 
 	fonts map[string]*Font
 
 	wndLayout *Layout
 
+	//containers in the stack order, the last one is on the top
 	containers []*Container
 
+	Mouse *mouse
+	Keys  *keyboard
+
 	ActiveWidget   *Widget
 	HoverWidget    *Widget
 	HoverContainer *Container
 
-	renderer *forward.ForwardRenderer
-)
+	focused      *Widget //widget notified by OnFocus
+	focusVisible bool    //focus is moved by the keyboard, the focus ring is drawn
 
-//Init gui
-func Init(glfwWindow *glfw.Window, graphProv graphics.GraphicsProvider) error {
-	window = glfwWindow
-	gfx = graphProv
+	//StopPropagation keeps the events handled by the gui from the window callbacks installed before it:
+	//the key presses and the chars while WantsKeyboard, the scroll while WantsMouse, key releases are always passed on
+	StopPropagation bool
+	mouseCaptured   bool //mouse button is held after the press on the gui
 
-	fonts = make(map[string]*Font)
-	frameTime = time.Now()
+	widgetMouse widgetMouse
 
-	vao = gfx.GenVertexArray()
-	comboVBO = gfx.GenBuffer()
-	indexVBO = gfx.GenBuffer()
+	//GamepadMapping is used if the host implements GamepadHost
+	GamepadMapping GamepadMapping
+	gamepad        gamepad
+	pendingNav     []NavAction
+}
 
-	var err error
-	mainShader, err = compileShader(ShaderV, ShaderF)
-	if err != nil {
-		return err
+//NewGUI creates a new independent gui working with the host window and drawn by the renderer
+func NewGUI(host Host, renderer Renderer) *GUI {
+	g := &GUI{
+		host:      host,
+		renderer:  renderer,
+		fonts:     make(map[string]*Font),
+		zcmds:     make(map[uint8][]*cmdList),
+		frameTime: time.Now(),
+
+		GamepadMapping: DefaultGamepadMapping,
 	}
 
-	wndLayout = &Layout{}
-	updateWindowLayout()
-	initMouse(window)
-	initKeyboard(window)
+	g.wndLayout = &Layout{}
+	g.updateWindowLayout()
+	g.Mouse = newMouse(g.host, func() bool { return g.StopPropagation && g.WantsMouse() })
+	g.Keys = newKeyboard(g.host, func() bool { return g.StopPropagation && g.WantsKeyboard() })
+
+	defaultStylesOnce.Do(initDefaultStyles)
+
+	return g
+}
 
-	initDefaultStyles()
+//Init creates the default gui working with the host and drawn by OpenGL,
+//package level functions will work with it, see glfwhost.Init for GLFW windows
+func Init(host Host, graphProv graphics.GraphicsProvider) error {
+	renderer, err := NewGLRenderer(graphProv)
+	if err != nil {
+		return err
+	}
 
+	defaultGUI = NewGUI(host, renderer)
 	return nil
 }
 
-func updateWindowLayout() {
-	w, h := window.GetSize()
-	wndLayout.X = 0
-	wndLayout.Y = float32(h) // for top left anchor
-	wndLayout.W = float32(w)
-	wndLayout.H = float32(h)
+//Default returns the gui created by Init
+func Default() *GUI {
+	return defaultGUI
+}
+
+func (g *GUI) updateWindowLayout() {
+	w, h := g.host.GetSize()
+	g.wndLayout.X = 0
+	g.wndLayout.Y = float32(h) // for top left anchor
+	g.wndLayout.W = float32(w)
+	g.wndLayout.H = float32(h)
 }
 
+//DelContainer removes container from the default gui
 func DelContainer(ptr *Container) {
-	for i, c := range containers {
+	defaultGUI.DelContainer(ptr)
+}
+
+//DelContainer removes container from the gui
+func (g *GUI) DelContainer(ptr *Container) {
+	found := false
+	for i, c := range g.containers {
 		if c == ptr {
-			containers[i] = nil
-			containers = append(containers[:i], containers[i+1:]...)
-			return
+			g.containers[i] = nil
+			g.containers = append(g.containers[:i], g.containers[i+1:]...)
+			found = true
+			break
 		}
 	}
+	if !found {
+		log.Println("WARNING: container not found")
+		return
+	}
 
-	log.Println("WARNING: container not found")
+	//popups of the widgets in the container are removed with it
+	var popups []*Container
+	for _, c := range g.containers {
+		if c.owner != nil && c.owner.Container.root() == ptr {
+			popups = append(popups, c)
+		}
+	}
+	for _, c := range popups {
+		c.onClose()
+		g.DelContainer(c)
+	}
+}
+
+//closePopups hides the popups which owner is not constructed in the frame:
+//its container is hidden, collapsed or removed
+func (g *GUI) closePopups() {
+	for _, c := range g.containers {
+		if c.owner != nil && !c.Hidden && c.owner.frame != g.frame {
+			c.onClose()
+		}
+	}
+}
+
+// Construct builds and renders the default gui, see GUI.Construct
+func Construct() {
+	defaultGUI.Construct()
 }
 
 // Construct loops through all of the Windows in the Manager and creates all of the widgets and their data.
 // This function does not buffer the result to VBO or do the actual rendering -- call Draw() for that.
-func Construct() {
+func (g *GUI) Construct() {
 	// fmt.Println("===============================")
 	// reset the display data
-	comboBuffer = comboBuffer[:0]
-	indexBuffer = indexBuffer[:0]
-	faceCount = 0
-	zcmds = make(map[uint8][]*cmdList)
+	g.zcmds = make(map[uint8][]*cmdList)
+	g.clips = g.clips[:0]
 
 	// textureStack = textureStack[:0]
 	t := time.Now()
-	dt = float32(t.Sub(frameTime).Seconds())
-	frameTime = t
+	g.dt = float32(t.Sub(g.frameTime).Seconds())
+	g.frameTime = t
+	g.frame++
 
-	Mouse.Update()
-	updateWindowLayout()
+	g.Mouse.Update()
+	g.Keys.Update()
+	g.updateWindowLayout()
 
-	HoverContainer = nil
-	for _, c := range containers {
-		if c.Hidden {
-			continue
-		}
-		if c.Layout.ContainsPoint(Mouse.X, Mouse.Y) {
-			HoverContainer = c
-			break
-		}
+	g.hitTest()
+	g.captureMouse()
+	g.dispatchMouse()
+
+	//a click raises the container to the top of the stack
+	if c := g.HoverContainer; c != nil && !c.root().PassThrough && g.Mouse.JustPressed(0) {
+		c.BringToFront()
 	}
 
-	HoverWidget = nil
+	g.updateNav()
+	g.updateFocus()
+
+	//widgets may raise or remove containers while they are constructed
+	containers := append([]*Container(nil), g.containers...)
 
+	root := g.zcmds
 	for _, c := range containers {
+		c.zcmds = nil
 		if c.Hidden {
 			continue
 		}
-		for _, wgt := range c.Widgets {
-			if wgt.Layout.ContainsPoint(Mouse.X, Mouse.Y) {
 
-				if HoverWidget == nil {
-					HoverWidget = wgt
-				} else if wgt.Z > HoverWidget.Z {
-					HoverWidget = wgt
-				}
-
-			}
-		}
+		c.zcmds = make(map[uint8][]*cmdList)
+		g.zcmds = c.zcmds
+		c.construct()
 	}
+	g.zcmds = root
+	g.closePopups()
 
-	for _, c := range containers {
-		if c != nil && !c.Hidden {
-			c.construct()
+	g.moveFocus()
+	g.notifyFocus()
+	g.render()
+}
+
+//stack returns visible containers in the draw order: sorted by Zorder, then by the stack order
+func (g *GUI) stack() []*Container {
+	stack := make([]*Container, 0, len(g.containers))
+	for _, c := range g.containers {
+		if !c.Hidden {
+			stack = append(stack, c)
 		}
 	}
 
-	render()
-}
+	sort.SliceStable(stack, func(i, j int) bool {
+		return stack[i].Zorder < stack[j].Zorder
+	})
 
-func render() {
-	const floatSize = 4
-	const uintSize = 4
+	return stack
+}
 
-	const minZDepth = -100
-	const maxZDepth = 100
+//hitTest finds the hovered container and widget walking the stack from the top,
+//containers except the PassThrough ones block the mouse for the containers below,
+//nested containers are tested through their panels
+func (g *GUI) hitTest() {
+	g.HoverContainer = nil
+	g.HoverWidget = nil
 
-	// gfx.Scissor(0, 0, int32(wndLayout.W), int32(wndLayout.H))
+	x, y := g.Mouse.X, g.Mouse.Y
 
-	var startIndex uint32
-	var z uint8
-	for z = 0; z < 255; z++ {
-		cmds, ok := zcmds[z]
-		if !ok {
+	stack := g.stack()
+	for i := len(stack) - 1; i >= 0; i-- {
+		c := stack[i]
+		if !c.Layout.ContainsPoint(x, y) {
 			continue
 		}
-		for _, cmd := range cmds {
-			if cmd.faceCount == 0 {
-				continue
-			}
-			comboBuffer = append(comboBuffer, cmd.comboBuffer...)
 
-			// reindex the index buffer to reference the correct vertex data
-			highestIndex := uint32(0)
-			for _, i := range cmd.indexBuffer {
-				if i > highestIndex {
-					highestIndex = i
-				}
-				indexBuffer = append(indexBuffer, i+startIndex)
-			}
-			faceCount += cmd.faceCount
-			startIndex += highestIndex + 1
+		hc, hw := c.hitTest(x, y)
+		if hw != nil || !c.PassThrough {
+			g.HoverContainer = hc
+			g.HoverWidget = hw
+			return
 		}
 	}
+}
 
-	// make sure that we're going to draw something
-	if startIndex == 0 {
-		return
+//captureMouse keeps the mouse for the gui from the press on it to the release of all buttons,
+//so the drag started on the gui is not seen by the game when the mouse leaves it
+func (g *GUI) captureMouse() {
+	held := false
+	for button := 0; button < 3; button++ {
+		if g.Mouse.JustPressed(button) {
+			g.mouseCaptured = g.HoverContainer != nil
+		}
+		if g.Mouse.GetButtonAction(button) == MouseDown {
+			held = true
+		}
 	}
 
-	gfx.Disable(graphics.DEPTH_TEST)
-	// gfx.Enable(graphics.SCISSOR_TEST)
-
-	gfx.BindVertexArray(vao)
-	view := mgl.Ortho(0.5, wndLayout.W+0.5, 0.5, wndLayout.H+0.5, minZDepth, maxZDepth)
+	if !held {
+		g.mouseCaptured = false
+	}
+}
 
-	// buffer the data
-	gfx.BindBuffer(graphics.ARRAY_BUFFER, comboVBO)
-	gfx.BufferData(graphics.ARRAY_BUFFER, floatSize*len(comboBuffer), gfx.Ptr(&comboBuffer[0]), graphics.STREAM_DRAW)
-	gfx.BindBuffer(graphics.ELEMENT_ARRAY_BUFFER, indexVBO)
-	gfx.BufferData(graphics.ELEMENT_ARRAY_BUFFER, uintSize*len(indexBuffer), gfx.Ptr(&indexBuffer[0]), graphics.STREAM_DRAW)
+//WantsMouse reports whether the mouse is over the gui or drags from it,
+//the application should ignore the mouse of this frame then
+func (g *GUI) WantsMouse() bool {
+	return g.HoverContainer != nil || g.mouseCaptured
+}
 
-	// texID = make(map[graphics.Texture]int32)
+//WantsKeyboard reports whether a widget has the keyboard focus,
+//the application should ignore the keys of this frame then
+func (g *GUI) WantsKeyboard() bool {
+	return g.ActiveWidget != nil
+}
 
-	bindShader(view)
+//scrollTarget returns the hovered container or its nearest scrollable parent
+func (g *GUI) scrollTarget() *Container {
+	c := g.HoverContainer
+	for c != nil && !c.IsScrollable {
+		c = c.parent
+	}
+	return c
+}
 
-	// var prevTex graphics.Texture
-	var indexOffset int
-	for z = 0; z < 255; z++ {
-		cmds, ok := zcmds[z]
-		if !ok {
-			continue
+//BringToFront moves the top level container of this one to the top of containers with the same Zorder
+func (c *Container) BringToFront() {
+	c = c.root()
+	g := c.gui
+	for i, ci := range g.containers {
+		if ci == c {
+			g.containers = append(g.containers[:i], g.containers[i+1:]...)
+			g.containers = append(g.containers, c)
+			return
 		}
+	}
+}
 
-		for _, cmd := range cmds {
-			if cmd.faceCount == 0 {
-				continue
-			}
+//render draws the layers of containers in the stack order, each layer is drawn by z
+func (g *GUI) render() {
+	var batches []Batch
 
-			// TEX := gfx.GetUniformLocation(mainShader, "TEX")
-			gfx.BindTexture(graphics.TEXTURE_2D, cmd.texture)
-			// if prevTex != cmd.texture {
-			// 	prevTex = cmd.texture
-			// 	bindShader(view, cmd.texture)
-			// }
+	layers := []map[uint8][]*cmdList{g.zcmds}
+	for _, c := range g.stack() {
+		layers = append(layers, c.zcmds)
+	}
 
-			gfx.Viewport(0, 0, int32(wndLayout.W), int32(wndLayout.H))
-			gfx.DrawElements(graphics.TRIANGLES, int32(cmd.faceCount*3), graphics.UNSIGNED_INT, gfx.PtrOffset(indexOffset*uintSize))
-			indexOffset += int(cmd.faceCount) * 3
+	for _, zcmds := range layers {
+		var z uint8
+		for z = 0; z < 255; z++ {
+			cmds, ok := zcmds[z]
+			if !ok {
+				continue
+			}
+			for _, cmd := range cmds {
+				if cmd.faceCount == 0 {
+					continue
+				}
+				batches = append(batches, Batch{
+					ComboBuffer: cmd.comboBuffer,
+					IndexBuffer: cmd.indexBuffer,
+					Faces:       cmd.faceCount,
+					Texture:     cmd.texture,
+					Clip:        cmd.clip,
+				})
+			}
 		}
 	}
 
-	gfx.BindVertexArray(0)
-
-	// gfx.Disable(graphics.SCISSOR_TEST)
-	gfx.Enable(graphics.DEPTH_TEST)
+	g.renderer.Render(g.wndLayout.W, g.wndLayout.H, batches)
 }
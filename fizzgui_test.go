@@ -0,0 +1,114 @@
+package fizzgui
+
+import (
+	"image/color"
+	"testing"
+	"time"
+)
+
+//newTestGUI creates the gui drawn by the software renderer to the memory host with the default font
+func newTestGUI(t *testing.T, w, h int) (*GUI, *MemoryHost, *SoftwareRenderer) {
+	t.Helper()
+
+	host := NewMemoryHost(w, h)
+	r := NewSoftwareRenderer()
+	r.ClearColor = color.RGBA{100, 100, 100, 255}
+
+	g := NewGUI(host, r)
+	if _, err := g.NewFont("Default", "examples/assets/Roboto-Bold.ttf", 16, FontGlyphs); err != nil {
+		t.Fatal(err)
+	}
+
+	return g, host, r
+}
+
+//click moves the cursor to the center of the widget and clicks it,
+//the frames before it settle the layout, the min sizes are applied in the next frame
+func click(g *GUI, host *MemoryHost, wgt *Widget) {
+	g.Construct()
+	g.Construct()
+
+	r := wgt.Layout.GetBackgroundRect()
+	clickPoint(g, host, r.TLX+r.W/2, r.TLY-r.H/2)
+}
+
+//clickPoint clicks the left button at the point in the gui coordinates
+func clickPoint(g *GUI, host *MemoryHost, x, y float32) {
+	moveCursor(g, host, x, y)
+	host.PressButton(0)
+	g.Construct()
+	host.ReleaseButton(0)
+	g.Construct()
+}
+
+//waitClicks waits until the next click is not counted as the double click
+func waitClicks(g *GUI) {
+	time.Sleep(time.Duration(g.Mouse.doubleClickThreshold*float64(time.Second)) + 10*time.Millisecond)
+}
+
+//moveCursor moves the cursor to the point in the gui coordinates, Y goes up
+func moveCursor(g *GUI, host *MemoryHost, x, y float32) {
+	host.MoveCursor(float64(x), float64(host.Height)-float64(y))
+	g.Construct()
+}
+
+//drag presses the left button at the first point, moves the cursor to the second one and releases it
+func drag(g *GUI, host *MemoryHost, x0, y0, x1, y1 float32) {
+	moveCursor(g, host, x0, y0)
+	host.PressButton(0)
+	g.Construct()
+	moveCursor(g, host, x1, y1)
+	host.ReleaseButton(0)
+	g.Construct()
+}
+
+func TestButtonClick(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+
+	var clicked *Widget
+	btn := c.NewButton("button", func(wgt *Widget) { clicked = wgt })
+	other := c.NewButton("other", nil)
+	g.Construct()
+
+	click(g, host, other)
+	if clicked != nil {
+		t.Fatal("callback of the other button is called")
+	}
+
+	click(g, host, btn)
+	if clicked != btn {
+		t.Fatal("button callback is not called")
+	}
+}
+
+func TestInputTyping(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+
+	text := "hello"
+	entered := 0
+	in := c.NewInput("in", &text, func(*Widget) { entered++ })
+	g.Construct()
+
+	click(g, host, in)
+	if g.ActiveWidget != in {
+		t.Fatal("input is not focused by the click")
+	}
+
+	host.TypeKey(KeyEnd, 0)
+	g.Construct()
+	host.TypeString(", world")
+	g.Construct()
+	host.TypeKey(KeyBackspace, 0)
+	g.Construct()
+	if text != "hello, worl" {
+		t.Fatalf("text is %q", text)
+	}
+
+	host.TypeKey(KeyEnter, 0)
+	g.Construct()
+	if entered != 1 || g.ActiveWidget != nil {
+		t.Fatal("enter does not finish the input", entered)
+	}
+}
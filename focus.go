@@ -0,0 +1,152 @@
+package fizzgui
+
+import "sort"
+
+//Focus moves the keyboard focus to the widget and shows the focus ring, nil removes the focus
+func (g *GUI) Focus(wgt *Widget) {
+	g.ActiveWidget = wgt
+	g.focusVisible = wgt != nil
+
+	if wgt != nil {
+		wgt.Container.ScrollTo(wgt)
+		wgt.Container.BringToFront()
+	}
+}
+
+//FocusNext moves the keyboard focus to the next widget in the tab order,
+//the previous one if reverse is true, the order is wrapped around
+func (g *GUI) FocusNext(reverse bool) {
+	list := g.focusable()
+	if len(list) == 0 {
+		return
+	}
+
+	i := -1
+	for j, wgt := range list {
+		if wgt == g.ActiveWidget {
+			i = j
+			break
+		}
+	}
+
+	switch {
+	case i < 0 && reverse:
+		i = len(list) - 1
+	case i < 0:
+		i = 0
+	case reverse:
+		i = (i - 1 + len(list)) % len(list)
+	default:
+		i = (i + 1) % len(list)
+	}
+
+	g.Focus(list[i])
+}
+
+//focusable returns visible widgets accepting the keyboard focus ordered by TabIndex,
+//widgets with the same TabIndex are in order of the containers and the widgets in them
+func (g *GUI) focusable() (list []*Widget) {
+	for _, c := range g.containers {
+		list = c.focusable(list)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].TabIndex < list[j].TabIndex
+	})
+
+	return list
+}
+
+//focusable appends the focusable widgets of the container and its nested containers
+func (c *Container) focusable(list []*Widget) []*Widget {
+	if c.Hidden || c.Collapsed {
+		return list
+	}
+
+	for _, wgt := range c.Widgets {
+		if wgt.Hidden {
+			continue
+		}
+
+		if child, ok := wgt.ConstructorData.(*Container); ok && child.panel == wgt {
+			list = child.focusable(list)
+			continue
+		}
+
+		if wgt.Focusable {
+			list = append(list, wgt)
+		}
+	}
+
+	return list
+}
+
+//updateFocus handles Tab and Shift+Tab before the widgets are constructed, the handled keys
+//are not seen by the widgets, a mouse press hides the focus ring
+func (g *GUI) updateFocus() {
+	if g.Mouse.JustPressed(0) {
+		g.focusVisible = false
+	}
+
+	keys := g.Keys.keys[:0]
+	for _, k := range g.Keys.keys {
+		if k.KeyCode == KeyTab && !k.Ctrl() && !k.Alt() && !k.Super() {
+			g.FocusNext(k.Shift())
+			continue
+		}
+		keys = append(keys, k)
+	}
+	g.Keys.keys = keys
+}
+
+//notifyFocus calls OnBlur and OnFocus callbacks when the focused widget is changed in the frame
+func (g *GUI) notifyFocus() {
+	prev := g.focused
+	if prev == g.ActiveWidget {
+		return
+	}
+	g.focused = g.ActiveWidget
+
+	if prev != nil && prev.OnBlur != nil {
+		prev.OnBlur(prev)
+	}
+	if g.focused != nil && g.focused.OnFocus != nil {
+		g.focused.OnFocus(g.focused)
+	}
+}
+
+//IsFocused reports whether the widget has the keyboard focus
+func (wgt *Widget) IsFocused() bool {
+	return wgt.Container.gui.ActiveWidget == wgt
+}
+
+//keyActivated reports whether Enter or Space is pressed while the widget has the focus
+func (wgt *Widget) keyActivated() bool {
+	if !wgt.IsFocused() {
+		return false
+	}
+
+	for _, k := range wgt.Container.gui.Keys.GetKeys() {
+		switch k.KeyCode {
+		case KeyEnter, KeyKPEnter, KeySpace:
+			return true
+		}
+	}
+	return false
+}
+
+//renderFocusRing draws the ring around the widget focused by the keyboard
+func (wgt *Widget) renderFocusRing(r Rect) {
+	gui := wgt.Container.gui
+	if !gui.focusVisible || gui.ActiveWidget != wgt || !DefaultFocusRingStyle.exist {
+		return
+	}
+
+	style := DefaultFocusRingStyle
+	r.TLX -= style.BorderWidth
+	r.TLY += style.BorderWidth
+	r.BRX += style.BorderWidth
+	r.BRY -= style.BorderWidth
+
+	renderBorder(gui.GetLastCmd(wgt.Z), r, style)
+}
@@ -0,0 +1,81 @@
+package fizzgui
+
+import "testing"
+
+func TestTabOrder(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "280px")
+
+	one := c.NewButton("one", nil)
+	two := c.NewButton("two", nil)
+	c.NewRow()
+	ok := false
+	chk := c.NewCheckboxLabel(&ok, "check", nil)
+	c.NewRow()
+	text := ""
+	in := c.NewInput("in", &text, nil)
+
+	//the lower TabIndex goes first
+	two.TabIndex = -1
+	g.Construct()
+
+	for i, want := range []*Widget{two, one, chk, in, two} {
+		host.TypeKey(KeyTab, 0)
+		g.Construct()
+		if g.ActiveWidget != want {
+			t.Fatalf("tab %d focuses %v", i, g.ActiveWidget)
+		}
+	}
+
+	host.TypeKey(KeyTab, ModShift)
+	g.Construct()
+	if g.ActiveWidget != in {
+		t.Fatal("shift+tab does not wrap to the last widget", g.ActiveWidget)
+	}
+
+	//the mouse press hides the focus ring and drops the focus outside of the widgets
+	moveCursor(g, host, 395, 5)
+	host.PressButton(0)
+	g.Construct()
+	host.ReleaseButton(0)
+	g.Construct()
+	if g.ActiveWidget != nil || g.focusVisible {
+		t.Fatal("click outside keeps the focus", g.ActiveWidget)
+	}
+}
+
+func TestFocusActivation(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "280px")
+
+	clicks := 0
+	btn := c.NewButton("button", func(*Widget) { clicks++ })
+	ok := false
+	chk := c.NewCheckboxLabel(&ok, "check", nil)
+
+	var events []string
+	btn.OnFocus = func(*Widget) { events = append(events, "focus") }
+	btn.OnBlur = func(*Widget) { events = append(events, "blur") }
+	g.Construct()
+
+	g.Focus(btn)
+	host.TypeKey(KeyEnter, 0)
+	g.Construct()
+	host.TypeKey(KeySpace, 0)
+	g.Construct()
+	if clicks != 2 {
+		t.Fatal("enter and space press the focused button", clicks)
+	}
+
+	host.TypeKey(KeyTab, 0)
+	g.Construct()
+	host.TypeKey(KeySpace, 0)
+	g.Construct()
+	if !ok || g.ActiveWidget != chk {
+		t.Fatal("space does not check the focused checkbox")
+	}
+
+	if len(events) != 2 || events[0] != "focus" || events[1] != "blur" {
+		t.Fatal("focus callbacks are", events)
+	}
+}
@@ -55,38 +55,47 @@ type Font struct {
 	locations   map[rune]runeData
 	opts        truetype.Options
 	face        imgfont.Face
+	renderer    Renderer
 }
 
-// NewFont loads the font from a file and 'registers' it with the UI manager.
+// NewFont loads the font from a file and 'registers' it with the default gui.
 func NewFont(name string, fontFilepath string, scaleInt int, glyphs string) (*Font, error) {
+	return defaultGUI.NewFont(name, fontFilepath, scaleInt, glyphs)
+}
+
+// NewFont loads the font from a file and 'registers' it with the gui.
+func (g *GUI) NewFont(name string, fontFilepath string, scaleInt int, glyphs string) (*Font, error) {
 
 	fontBytes, err := ioutil.ReadFile(fontFilepath)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to load font from path: '%s' \n%v", fontFilepath, err)
 	}
 
-	f, err := newFont(fontBytes, scaleInt, glyphs)
-	if err != nil {
-		return nil, err
-	}
-	fonts[name] = f
-
-	return f, nil
+	return g.LoadFont(name, fontBytes, scaleInt, glyphs)
 }
 
 func LoadFont(name string, fontBytes []byte, scaleInt int, glyphs string) (*Font, error) {
-	f, err := newFont(fontBytes, scaleInt, glyphs)
+	return defaultGUI.LoadFont(name, fontBytes, scaleInt, glyphs)
+}
+
+func (g *GUI) LoadFont(name string, fontBytes []byte, scaleInt int, glyphs string) (*Font, error) {
+	f, err := newFont(g.renderer, fontBytes, scaleInt, glyphs)
 	if err != nil {
 		return nil, err
 	}
-	fonts[name] = f
+	g.fonts[name] = f
 	return f, nil
 }
 
-// GetFont attempts to get the font by name from the Manager's collection
-// It returns the font on success or nil on failure.
+// GetFont attempts to get the font by name from the default gui.
 func GetFont(name string) *Font {
-	f, ok := fonts[name]
+	return defaultGUI.GetFont(name)
+}
+
+// GetFont attempts to get the font by name from the gui's collection
+// It returns the font on success or nil on failure.
+func (g *GUI) GetFont(name string) *Font {
+	f, ok := g.fonts[name]
 	if !ok {
 		log.Fatalf("font by name '%s' not loaded", name)
 	}
@@ -94,8 +103,9 @@ func GetFont(name string) *Font {
 }
 
 // LoadFont uses the Go freetype library to parse it and render the specified glyphs to a texture that is then buffered into OpenGL.
-func newFont(fontBytes []byte, scaleInt int, glyphs string) (f *Font, e error) {
+func newFont(renderer Renderer, fontBytes []byte, scaleInt int, glyphs string) (f *Font, e error) {
 	f = new(Font)
+	f.renderer = renderer
 	scale := fixed.I(scaleInt)
 
 	// allocate the location map
@@ -199,19 +209,19 @@ func newFont(fontBytes []byte, scaleInt int, glyphs string) (f *Font, e error) {
 	// set the white point
 	fontImg.SetRGBA(fontTexSize-1, fontTexSize-1, color.RGBA{R: 255, G: 255, B: 255, A: 255})
 
-	// buffer the font image into an OpenGL texture
+	// buffer the font image into a texture of the renderer
 	f.Glyphs = glyphs
 	f.TextureSize = fontTexSize
 	f.GlyphWidth = glyphWidth
 	f.GlyphHeight = glyphHeight
-	f.Texture = loadRGBAToTexture(fontImg.Pix, int32(fontImg.Rect.Max.X))
+	f.Texture = renderer.NewTexture(fontImg, false)
 
 	return
 }
 
-// Destroy releases the OpenGL texture for the font.
+// Destroy releases the texture for the font.
 func (f *Font) Destroy() {
-	gfx.DeleteTexture(f.Texture)
+	f.renderer.DeleteTexture(f.Texture)
 }
 
 // GetCurrentScale returns the scale value for the font based on the current
@@ -328,6 +338,11 @@ func (f *Font) OffsetForIndexAdv(msg string, charStartIndex int, stopIndex int)
 	return w * fontScale
 }
 
+// RuneAdvance returns the width the rune takes in the rendered text.
+func (f *Font) RuneAdvance(ch rune) float32 {
+	return f.locations[ch].advanceWidth * f.GetCurrentScale()
+}
+
 // fixedInt26ToFloat converts a fixed int 26:6 precision to a float32.
 func fixedInt26ToFloat(fixedInt fixed.Int26_6) float32 {
 	var result float32
@@ -364,13 +379,16 @@ func (f *Font) CreateTextAdv(pos mgl.Vec2, color mgl.Vec4, maxWidth float32, cha
 	// the library always binds the font to the first texture sampler.
 	const floatTexturePosition = 0.0
 
-	// // sanity checks
-	// originalLen := len(msg)
-	// trimmedMsg := msg
-	// if charOffset > 0 && charOffset < originalLen {
-	// 	// trim the string based on incoming character offset
-	// 	trimmedMsg = trimmedMsg[charOffset:]
-	// }
+	// trim the string based on incoming character offset
+	if charOffset > 0 {
+		runes := []rune(s)
+		if charOffset > len(runes) {
+			charOffset = len(runes)
+		}
+		s = string(runes[charOffset:])
+	} else {
+		charOffset = 0
+	}
 
 	// get the length of our message
 	l := len(s)
@@ -421,7 +439,7 @@ func (f *Font) CreateTextAdv(pos mgl.Vec2, color mgl.Vec4, maxWidth float32, cha
 			// the cursor position is covered within this string or if that hasn't
 			// been reached yet.
 			if cursorPosition >= 0 && cursorPosition-charOffset > chi {
-				// cursorOverflowRight = true
+				cursorOverflowRight = true
 			}
 
 			// adjust the dimX here since we shortened the string
@@ -496,26 +514,3 @@ func (f *Font) CreateTextAdv(pos mgl.Vec2, color mgl.Vec4, maxWidth float32, cha
 		CursorOverflowRight: cursorOverflowRight,
 	}
 }
-
-// loadRGBAToTexture takes a byte slice and throws it into an OpenGL texture.
-func loadRGBAToTexture(rgba []byte, imageSize int32) graphics.Texture {
-	return loadRGBAToTextureExt(rgba, imageSize, graphics.NEAREST, graphics.NEAREST, graphics.CLAMP_TO_EDGE, graphics.CLAMP_TO_EDGE)
-}
-
-// loadRGBAToTextureExt takes a byte slice and throws it into an OpenGL texture.
-func loadRGBAToTextureExt(rgba []byte, imageSize, magFilter, minFilter, wrapS, wrapT int32) graphics.Texture {
-	tex := gfx.GenTexture()
-
-	gfx.ActiveTexture(graphics.TEXTURE0)
-	gfx.BindTexture(graphics.TEXTURE_2D, tex)
-	// gfx.GenerateMipmap(graphics.TEXTURE_2D)
-
-	gfx.TexParameteri(graphics.TEXTURE_2D, graphics.TEXTURE_MAG_FILTER, magFilter)
-	gfx.TexParameteri(graphics.TEXTURE_2D, graphics.TEXTURE_MIN_FILTER, minFilter)
-
-	gfx.TexParameteri(graphics.TEXTURE_2D, graphics.TEXTURE_WRAP_S, wrapS)
-	gfx.TexParameteri(graphics.TEXTURE_2D, graphics.TEXTURE_WRAP_T, wrapT)
-
-	gfx.TexImage2D(graphics.TEXTURE_2D, 0, graphics.RGBA, imageSize, imageSize, 0, graphics.RGBA, graphics.UNSIGNED_BYTE, gfx.Ptr(rgba), len(rgba))
-	return tex
-}
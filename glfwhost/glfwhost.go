@@ -0,0 +1,89 @@
+//Package glfwhost connects fizzgui to a GLFW window, it is the only part of the gui linked with cgo GLFW
+package glfwhost
+
+import (
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/sg3des/fizzgui"
+	graphics "github.com/tbogdala/fizzle/graphicsprovider"
+)
+
+//Init gui drawn to the glfw window, package level functions of fizzgui will work with it
+func Init(glfwWindow *glfw.Window, graphProv graphics.GraphicsProvider) error {
+	return fizzgui.Init(New(glfwWindow), graphProv)
+}
+
+//glfwHost is the Host working with a GLFW window,
+//previously installed window callbacks are called after the gui ones unless the gui handled the event
+type glfwHost struct {
+	window *glfw.Window
+
+	prevScrollCallback   glfw.ScrollCallback
+	prevKeyCallback      glfw.KeyCallback
+	prevCharModsCallback glfw.CharModsCallback
+}
+
+//New creates Host for the glfw window
+func New(window *glfw.Window) fizzgui.Host {
+	return &glfwHost{window: window}
+}
+
+func (h *glfwHost) GetSize() (w, ht int) {
+	return h.window.GetSize()
+}
+
+func (h *glfwHost) GetCursorPos() (x, y float64) {
+	return h.window.GetCursorPos()
+}
+
+func (h *glfwHost) GetMouseButton(button int) bool {
+	action := h.window.GetMouseButton(glfw.MouseButton(int(glfw.MouseButton1) + button))
+	return action == glfw.Press || action == glfw.Repeat
+}
+
+//GetGamepad reads the first connected joystick
+func (h *glfwHost) GetGamepad() (axes []float32, buttons []bool, ok bool) {
+	for joy := glfw.Joystick1; joy <= glfw.JoystickLast; joy++ {
+		if !glfw.JoystickPresent(joy) {
+			continue
+		}
+
+		for _, b := range glfw.GetJoystickButtons(joy) {
+			buttons = append(buttons, glfw.Action(b) == glfw.Press)
+		}
+		return glfw.GetJoystickAxes(joy), buttons, true
+	}
+
+	return nil, nil, false
+}
+
+func (h *glfwHost) GetClipboardString() (string, error) {
+	return h.window.GetClipboardString()
+}
+
+func (h *glfwHost) SetClipboardString(s string) {
+	h.window.SetClipboardString(s)
+}
+
+func (h *glfwHost) SetScrollCallback(f fizzgui.ScrollCallback) {
+	h.prevScrollCallback = h.window.SetScrollCallback(func(w *glfw.Window, xoff float64, yoff float64) {
+		if !f(xoff, yoff) && h.prevScrollCallback != nil {
+			h.prevScrollCallback(w, xoff, yoff)
+		}
+	})
+}
+
+func (h *glfwHost) SetKeyCallback(f fizzgui.KeyCallback) {
+	h.prevKeyCallback = h.window.SetKeyCallback(func(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+		if !f(fizzgui.Key(key), scancode, fizzgui.Action(action), fizzgui.ModifierKey(mods)) && h.prevKeyCallback != nil {
+			h.prevKeyCallback(w, key, scancode, action, mods)
+		}
+	})
+}
+
+func (h *glfwHost) SetCharCallback(f fizzgui.CharCallback) {
+	h.prevCharModsCallback = h.window.SetCharModsCallback(func(w *glfw.Window, char rune, mods glfw.ModifierKey) {
+		if !f(char, fizzgui.ModifierKey(mods)) && h.prevCharModsCallback != nil {
+			h.prevCharModsCallback(w, char, mods)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package fizzgui
+
+import (
+	"image"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	graphics "github.com/tbogdala/fizzle/graphicsprovider"
+)
+
+//glRenderer draws the gui with the fizzle graphics provider
+type glRenderer struct {
+	gfx graphics.GraphicsProvider
+
+	mainShader graphics.Program
+
+	comboBuffer []float32
+	indexBuffer []uint32
+	comboVBO    graphics.Buffer
+	indexVBO    graphics.Buffer
+	vao         uint32
+	faceCount   uint32
+}
+
+//NewGLRenderer creates Renderer drawing with OpenGL through the graphics provider
+func NewGLRenderer(gfx graphics.GraphicsProvider) (Renderer, error) {
+	r := &glRenderer{gfx: gfx}
+
+	r.vao = r.gfx.GenVertexArray()
+	r.comboVBO = r.gfx.GenBuffer()
+	r.indexVBO = r.gfx.GenBuffer()
+
+	var err error
+	r.mainShader, err = r.compileShader(ShaderV, ShaderF)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// NewTexture takes the image pixels and throws them into an OpenGL texture.
+func (r *glRenderer) NewTexture(img *image.RGBA, smooth bool) graphics.Texture {
+	var filter int32 = graphics.NEAREST
+	var wrap int32 = graphics.CLAMP_TO_EDGE
+	if smooth {
+		filter = graphics.LINEAR
+		wrap = graphics.REPEAT
+	}
+
+	w := int32(img.Rect.Dx())
+	h := int32(img.Rect.Dy())
+
+	tex := r.gfx.GenTexture()
+
+	r.gfx.ActiveTexture(graphics.TEXTURE0)
+	r.gfx.BindTexture(graphics.TEXTURE_2D, tex)
+	// r.gfx.GenerateMipmap(graphics.TEXTURE_2D)
+
+	r.gfx.TexParameteri(graphics.TEXTURE_2D, graphics.TEXTURE_MAG_FILTER, filter)
+	r.gfx.TexParameteri(graphics.TEXTURE_2D, graphics.TEXTURE_MIN_FILTER, filter)
+
+	r.gfx.TexParameteri(graphics.TEXTURE_2D, graphics.TEXTURE_WRAP_S, wrap)
+	r.gfx.TexParameteri(graphics.TEXTURE_2D, graphics.TEXTURE_WRAP_T, wrap)
+
+	r.gfx.TexImage2D(graphics.TEXTURE_2D, 0, graphics.RGBA, w, h, 0, graphics.RGBA, graphics.UNSIGNED_BYTE, r.gfx.Ptr(img.Pix), len(img.Pix))
+	return tex
+}
+
+// DeleteTexture releases the OpenGL texture.
+func (r *glRenderer) DeleteTexture(tex graphics.Texture) {
+	r.gfx.DeleteTexture(tex)
+}
+
+// Render buffers all of the batches to the VBO and draws them one by one.
+func (r *glRenderer) Render(w, h float32, batches []Batch) {
+	const floatSize = 4
+	const uintSize = 4
+
+	const minZDepth = -100
+	const maxZDepth = 100
+
+	// reset the display data
+	r.comboBuffer = r.comboBuffer[:0]
+	r.indexBuffer = r.indexBuffer[:0]
+	r.faceCount = 0
+
+	var startIndex uint32
+	for _, b := range batches {
+		r.comboBuffer = append(r.comboBuffer, b.ComboBuffer...)
+
+		// reindex the index buffer to reference the correct vertex data
+		highestIndex := uint32(0)
+		for _, i := range b.IndexBuffer {
+			if i > highestIndex {
+				highestIndex = i
+			}
+			r.indexBuffer = append(r.indexBuffer, i+startIndex)
+		}
+		r.faceCount += b.Faces
+		startIndex += highestIndex + 1
+	}
+
+	// make sure that we're going to draw something
+	if startIndex == 0 {
+		return
+	}
+
+	r.gfx.Disable(graphics.DEPTH_TEST)
+	r.gfx.Enable(graphics.SCISSOR_TEST)
+
+	r.gfx.BindVertexArray(r.vao)
+	view := mgl.Ortho(0.5, w+0.5, 0.5, h+0.5, minZDepth, maxZDepth)
+
+	// buffer the data
+	r.gfx.BindBuffer(graphics.ARRAY_BUFFER, r.comboVBO)
+	r.gfx.BufferData(graphics.ARRAY_BUFFER, floatSize*len(r.comboBuffer), r.gfx.Ptr(&r.comboBuffer[0]), graphics.STREAM_DRAW)
+	r.gfx.BindBuffer(graphics.ELEMENT_ARRAY_BUFFER, r.indexVBO)
+	r.gfx.BufferData(graphics.ELEMENT_ARRAY_BUFFER, uintSize*len(r.indexBuffer), r.gfx.Ptr(&r.indexBuffer[0]), graphics.STREAM_DRAW)
+
+	r.bindShader(view)
+
+	var indexOffset int
+	for _, b := range batches {
+		r.gfx.BindTexture(graphics.TEXTURE_2D, b.Texture)
+
+		// pixel column i is centered at x=i+1 by the view matrix
+		clip := b.Clip
+		r.gfx.Scissor(int32(clip.TLX-1), int32(clip.BRY), int32(clip.W), int32(clip.H))
+
+		r.gfx.Viewport(0, 0, int32(w), int32(h))
+		r.gfx.DrawElements(graphics.TRIANGLES, int32(b.Faces*3), graphics.UNSIGNED_INT, r.gfx.PtrOffset(indexOffset*uintSize))
+		indexOffset += int(b.Faces) * 3
+	}
+
+	r.gfx.BindVertexArray(0)
+
+	r.gfx.Disable(graphics.SCISSOR_TEST)
+	r.gfx.Enable(graphics.DEPTH_TEST)
+}
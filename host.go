@@ -0,0 +1,160 @@
+package fizzgui
+
+//Host is the window and input backend the gui works with,
+//GLFW is one implementation, MemoryHost drives the gui from code
+type Host interface {
+	//GetSize returns the size of the window in screen coordinates
+	GetSize() (w, h int)
+	//GetCursorPos returns the cursor position relative to the top left corner of the window
+	GetCursorPos() (x, y float64)
+	//GetMouseButton reports whether the mouse button is held down, 0 is the left button
+	GetMouseButton(button int) bool
+
+	GetClipboardString() (string, error)
+	SetClipboardString(s string)
+
+	//SetScrollCallback, SetKeyCallback and SetCharCallback install the functions
+	//receiving the scroll, key and char events of the window
+	SetScrollCallback(f ScrollCallback)
+	SetKeyCallback(f KeyCallback)
+	SetCharCallback(f CharCallback)
+}
+
+//GamepadHost is implemented by the hosts reading the game controller,
+//the gui navigates the widgets by it if the host provides it
+type GamepadHost interface {
+	//GetGamepad returns the axes from -1 to 1 and the buttons held down
+	//of the first connected controller, ok is false if there is none
+	GetGamepad() (axes []float32, buttons []bool, ok bool)
+}
+
+//ScrollCallback receives scroll wheel offsets, see KeyCallback for the result
+type ScrollCallback func(xoff, yoff float64) (handled bool)
+
+//KeyCallback receives key presses, repeats and releases,
+//the handled event should not be passed to the callbacks installed before the gui
+type KeyCallback func(key Key, scancode int, action Action, mods ModifierKey) (handled bool)
+
+//CharCallback receives unicode characters typed by the user, see KeyCallback for the result
+type CharCallback func(char rune, mods ModifierKey) (handled bool)
+
+//Action is the state change of a key
+type Action int
+
+const (
+	Release Action = iota
+	Press
+	Repeat
+)
+
+//ModifierKey is a bit set of the modifier keys held down
+type ModifierKey int
+
+const (
+	ModShift ModifierKey = 1 << iota
+	ModControl
+	ModAlt
+	ModSuper
+)
+
+//Key is a keyboard key code, values are the same as in GLFW
+type Key int
+
+const (
+	KeyUnknown Key = -1
+
+	// printable keys
+	KeySpace        Key = 32
+	KeyApostrophe   Key = 39
+	KeyComma        Key = 44
+	KeyMinus        Key = 45
+	KeyPeriod       Key = 46
+	KeySlash        Key = 47
+	Key0            Key = 48
+	Key1            Key = 49
+	Key2            Key = 50
+	Key3            Key = 51
+	Key4            Key = 52
+	Key5            Key = 53
+	Key6            Key = 54
+	Key7            Key = 55
+	Key8            Key = 56
+	Key9            Key = 57
+	KeySemicolon    Key = 59
+	KeyEqual        Key = 61
+	KeyA            Key = 65
+	KeyB            Key = 66
+	KeyC            Key = 67
+	KeyD            Key = 68
+	KeyE            Key = 69
+	KeyF            Key = 70
+	KeyG            Key = 71
+	KeyH            Key = 72
+	KeyI            Key = 73
+	KeyJ            Key = 74
+	KeyK            Key = 75
+	KeyL            Key = 76
+	KeyM            Key = 77
+	KeyN            Key = 78
+	KeyO            Key = 79
+	KeyP            Key = 80
+	KeyQ            Key = 81
+	KeyR            Key = 82
+	KeyS            Key = 83
+	KeyT            Key = 84
+	KeyU            Key = 85
+	KeyV            Key = 86
+	KeyW            Key = 87
+	KeyX            Key = 88
+	KeyY            Key = 89
+	KeyZ            Key = 90
+	KeyLeftBracket  Key = 91
+	KeyBackslash    Key = 92
+	KeyRightBracket Key = 93
+	KeyGraveAccent  Key = 96
+	KeyWorld1       Key = 161
+	KeyWorld2       Key = 162
+
+	// function keys
+	KeyEscape       Key = 256
+	KeyEnter        Key = 257
+	KeyTab          Key = 258
+	KeyBackspace    Key = 259
+	KeyInsert       Key = 260
+	KeyDelete       Key = 261
+	KeyRight        Key = 262
+	KeyLeft         Key = 263
+	KeyDown         Key = 264
+	KeyUp           Key = 265
+	KeyPageUp       Key = 266
+	KeyPageDown     Key = 267
+	KeyHome         Key = 268
+	KeyEnd          Key = 269
+	KeyCapsLock     Key = 280
+	KeyScrollLock   Key = 281
+	KeyNumLock      Key = 282
+	KeyPrintScreen  Key = 283
+	KeyPause        Key = 284
+	KeyF1           Key = 290
+	KeyF2           Key = 291
+	KeyF3           Key = 292
+	KeyF4           Key = 293
+	KeyF5           Key = 294
+	KeyF6           Key = 295
+	KeyF7           Key = 296
+	KeyF8           Key = 297
+	KeyF9           Key = 298
+	KeyF10          Key = 299
+	KeyF11          Key = 300
+	KeyF12          Key = 301
+	KeyKPEnter      Key = 335
+	KeyLeftShift    Key = 340
+	KeyLeftControl  Key = 341
+	KeyLeftAlt      Key = 342
+	KeyLeftSuper    Key = 343
+	KeyRightShift   Key = 344
+	KeyRightControl Key = 345
+	KeyRightAlt     Key = 346
+	KeyRightSuper   Key = 347
+	KeyMenu         Key = 348
+)
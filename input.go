@@ -0,0 +1,1168 @@
+package fizzgui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+//textEdit contains the edited text and the caret, it is shared by the input and the text area
+type textEdit struct {
+	value *string
+	runes []rune
+	last  string //value at the last sync
+
+	cursor      int
+	anchor      int //other end of the selection, it is equal to the cursor if nothing is selected
+	cursorTimer float32
+	selecting   bool //the selection is dragged by the mouse
+
+	undo     []textState
+	redo     []textState
+	lastEdit int //kind of the last change, successive typing or deleting is undone at once
+
+	mask rune //rendered instead of every rune if it is set
+}
+
+//textState is the text with the caret saved in the history
+type textState struct {
+	runes          []rune
+	cursor, anchor int
+}
+
+//kinds of the changes in the history
+const (
+	editNone = iota
+	editTyping
+	editDeleting
+	editOther
+)
+
+//maxHistory is the number of the changes that can be undone
+const maxHistory = 100
+
+//caretWidth is the space taken by the caret after the rune
+const caretWidth = 3
+
+func newTextEdit(value *string) textEdit {
+	return textEdit{value: value, runes: []rune(*value), last: *value}
+}
+
+//sync reloads the text if the value was changed outside of the widget
+func (ed *textEdit) sync() {
+	if *ed.value == ed.last {
+		return
+	}
+
+	ed.runes = []rune(*ed.value)
+	ed.last = *ed.value
+	if ed.cursor > len(ed.runes) {
+		ed.cursor = len(ed.runes)
+	}
+	ed.anchor = ed.cursor
+	ed.lastEdit = editNone
+}
+
+//record saves the text before the change of the kind to the history,
+//the change continuing the typing or deleting run is joined with it
+func (ed *textEdit) record(kind int) {
+	if kind != editOther && kind == ed.lastEdit {
+		return
+	}
+	ed.lastEdit = kind
+
+	ed.undo = append(ed.undo, ed.state())
+	if len(ed.undo) > maxHistory {
+		ed.undo = ed.undo[1:]
+	}
+	ed.redo = ed.redo[:0]
+}
+
+func (ed *textEdit) state() textState {
+	return textState{
+		runes:  append([]rune{}, ed.runes...),
+		cursor: ed.cursor,
+		anchor: ed.anchor,
+	}
+}
+
+func (ed *textEdit) restore(s textState) {
+	ed.runes = s.runes
+	ed.cursor = s.cursor
+	ed.anchor = s.anchor
+	ed.cursorTimer = 0
+	ed.lastEdit = editNone
+}
+
+//undoEdit reverts the last change from the history
+func (ed *textEdit) undoEdit() {
+	if len(ed.undo) == 0 {
+		return
+	}
+
+	ed.redo = append(ed.redo, ed.state())
+	ed.restore(ed.undo[len(ed.undo)-1])
+	ed.undo = ed.undo[:len(ed.undo)-1]
+}
+
+//redoEdit applies the last undone change again
+func (ed *textEdit) redoEdit() {
+	if len(ed.redo) == 0 {
+		return
+	}
+
+	ed.undo = append(ed.undo, ed.state())
+	ed.restore(ed.redo[len(ed.redo)-1])
+	ed.redo = ed.redo[:len(ed.redo)-1]
+}
+
+func (ed *textEdit) clearHistory() {
+	ed.undo = nil
+	ed.redo = nil
+	ed.lastEdit = editNone
+}
+
+//typeRunes inserts the typed runes, the typing run is undone at once
+func (ed *textEdit) typeRunes(runes []rune) {
+	if len(runes) == 0 {
+		return
+	}
+
+	ed.record(editTyping)
+	ed.insert(runes)
+}
+
+//commit writes the text to the value
+func (ed *textEdit) commit() {
+	*ed.value = string(ed.runes)
+	ed.last = *ed.value
+}
+
+//insert replaces the selection by the runes
+func (ed *textEdit) insert(runes []rune) {
+	if len(runes) == 0 {
+		return
+	}
+
+	ed.deleteSelection()
+	ed.runes = append(ed.runes[:ed.cursor], append(append([]rune{}, runes...), ed.runes[ed.cursor:]...)...)
+	ed.cursor += len(runes)
+	ed.anchor = ed.cursor
+	ed.cursorTimer = 0
+}
+
+//backspace deletes the selection or the rune before the caret, the whole word before it if word is set
+func (ed *textEdit) backspace(word bool) {
+	if ed.hasSelection() {
+		ed.record(editDeleting)
+		ed.deleteSelection()
+		return
+	}
+
+	other := ed.cursor
+	if word {
+		other = ed.wordLeft(ed.cursor)
+	} else if ed.cursor > 0 {
+		other = ed.cursor - 1
+	}
+
+	//the history keeps the caret without the deleted range selected
+	if other != ed.cursor {
+		ed.record(editDeleting)
+		ed.anchor = other
+		ed.deleteSelection()
+	}
+}
+
+//delete deletes the selection or the rune after the caret, the word after it if word is set
+func (ed *textEdit) delete(word bool) {
+	if ed.hasSelection() {
+		ed.record(editDeleting)
+		ed.deleteSelection()
+		return
+	}
+
+	other := ed.cursor
+	if word {
+		other = ed.wordRight(ed.cursor)
+	} else if ed.cursor < len(ed.runes) {
+		other = ed.cursor + 1
+	}
+
+	if other != ed.cursor {
+		ed.record(editDeleting)
+		ed.anchor = other
+		ed.deleteSelection()
+	}
+}
+
+//wordLeft returns the start of the word before the caret position i, the spaces before i are skipped,
+//the words are split as described in class
+func (ed *textEdit) wordLeft(i int) int {
+	for i > 0 && ed.class(i-1) == classSpace {
+		i--
+	}
+	if i > 0 {
+		class := ed.class(i - 1)
+		for i > 0 && ed.class(i-1) == class {
+			i--
+		}
+	}
+	return i
+}
+
+//wordRight returns the start of the next word after the caret position i
+func (ed *textEdit) wordRight(i int) int {
+	if i < len(ed.runes) {
+		class := ed.class(i)
+		for i < len(ed.runes) && class != classSpace && ed.class(i) == class {
+			i++
+		}
+	}
+	for i < len(ed.runes) && ed.class(i) == classSpace {
+		i++
+	}
+	return i
+}
+
+//moveWord moves the caret to the previous word start if n is negative or to the next one
+func (ed *textEdit) moveWord(n int, extend bool) {
+	if n < 0 {
+		ed.moveTo(ed.wordLeft(ed.cursor), extend)
+	} else {
+		ed.moveTo(ed.wordRight(ed.cursor), extend)
+	}
+}
+
+//moveTo moves the caret to the rune index, the selection is extended to it or dropped
+func (ed *textEdit) moveTo(i int, extend bool) {
+	if i < 0 {
+		i = 0
+	}
+	if i > len(ed.runes) {
+		i = len(ed.runes)
+	}
+
+	ed.cursor = i
+	if !extend {
+		ed.anchor = i
+	}
+	ed.cursorTimer = 0
+	ed.lastEdit = editNone
+}
+
+//moveBy moves the caret by n runes, the collapsed selection leaves the caret at its side
+func (ed *textEdit) moveBy(n int, extend bool) {
+	if !extend && ed.hasSelection() {
+		start, end := ed.selection()
+		if n < 0 {
+			ed.moveTo(start, false)
+		} else {
+			ed.moveTo(end, false)
+		}
+		return
+	}
+
+	ed.moveTo(ed.cursor+n, extend)
+}
+
+//selection returns the selected runes [start, end)
+func (ed *textEdit) selection() (start, end int) {
+	if ed.anchor < ed.cursor {
+		return ed.anchor, ed.cursor
+	}
+	return ed.cursor, ed.anchor
+}
+
+func (ed *textEdit) hasSelection() bool {
+	return ed.anchor != ed.cursor
+}
+
+func (ed *textEdit) selectAll() {
+	ed.anchor = 0
+	ed.cursor = len(ed.runes)
+	ed.cursorTimer = 0
+	ed.lastEdit = editNone
+}
+
+//deleteSelection removes the selected runes, it returns false if nothing is selected
+func (ed *textEdit) deleteSelection() bool {
+	if !ed.hasSelection() {
+		return false
+	}
+
+	start, end := ed.selection()
+	ed.runes = append(ed.runes[:start], ed.runes[end:]...)
+	ed.cursor = start
+	ed.anchor = start
+	ed.cursorTimer = 0
+	return true
+}
+
+//copy puts the selected text to the clipboard, the masked text is not copied
+func (ed *textEdit) copy(gui *GUI) {
+	if ed.hasSelection() && ed.mask == 0 {
+		start, end := ed.selection()
+		gui.host.SetClipboardString(string(ed.runes[start:end]))
+	}
+}
+
+//cut moves the selected text to the clipboard
+func (ed *textEdit) cut(gui *GUI) {
+	if ed.hasSelection() && ed.mask == 0 {
+		ed.copy(gui)
+		ed.record(editOther)
+		ed.deleteSelection()
+	}
+}
+
+//selectWord selects the word, the spaces or the single symbol at the caret position i,
+//the word ending at it is preferred
+func (ed *textEdit) selectWord(i int) {
+	if i > 0 && ed.class(i-1) == classWord && (i == len(ed.runes) || ed.class(i) != classWord) {
+		i--
+	}
+	if i >= len(ed.runes) {
+		i = len(ed.runes) - 1
+	}
+	if i < 0 {
+		return
+	}
+
+	class := ed.class(i)
+	start, end := i, i+1
+	if class != classSymbol {
+		for start > 0 && ed.class(start-1) == class {
+			start--
+		}
+		for end < len(ed.runes) && ed.class(end) == class {
+			end++
+		}
+	}
+
+	ed.anchor = start
+	ed.cursor = end
+	ed.cursorTimer = 0
+	ed.lastEdit = editNone
+}
+
+//rune classes used to find the word boundaries
+const (
+	classSymbol = iota
+	classWord
+	classSpace
+)
+
+//class returns the class of the rune at the index, the masked text is one word.
+//The boundaries follow the main rules of UAX #29: combining marks belong to the rune before them,
+//apostrophes between letters ("don't") and points and commas between digits ("3.14") do not split the word.
+//The dictionary segmentation of the scripts without spaces (Thai, CJK) and the emoji sequences
+//are not supported, such text is split by the rune classes only
+func (ed *textEdit) class(i int) int {
+	if ed.mask != 0 {
+		return classWord
+	}
+
+	ch := ed.runes[i]
+	if i > 0 && unicode.In(ch, unicode.Mn, unicode.Me) {
+		return ed.class(i - 1)
+	}
+
+	if i > 0 && i+1 < len(ed.runes) {
+		prev, next := ed.runes[i-1], ed.runes[i+1]
+		switch ch {
+		case '\'', '’':
+			if unicode.IsLetter(prev) && unicode.IsLetter(next) {
+				return classWord
+			}
+		case '.', ',':
+			if unicode.IsDigit(prev) && unicode.IsDigit(next) {
+				return classWord
+			}
+		}
+	}
+
+	return runeClass(ch)
+}
+
+//runeClass returns the class of the rune without the context
+func runeClass(ch rune) int {
+	switch {
+	case unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '_':
+		return classWord
+	case unicode.IsSpace(ch):
+		return classSpace
+	}
+	return classSymbol
+}
+
+//dragSelection places the caret by the mouse press on the widget and extends the selection
+//to the index returned by indexAt while the button is held, the double click selects
+//the word and the triple click selects all
+func (ed *textEdit) dragSelection(wgt *Widget, indexAt func(x, y float32) int) {
+	mouse := wgt.Container.gui.Mouse
+
+	if mouse.GetButtonAction(0) != MouseDown {
+		ed.selecting = false
+		return
+	}
+
+	if mouse.JustPressed(0) && wgt.IsHover() {
+		i := indexAt(mouse.GetButtonDownPosition(0))
+
+		switch mouse.GetClickCount(0) {
+		case 1:
+			ed.selecting = true
+			ed.moveTo(i, false)
+		case 2:
+			ed.selectWord(i)
+		default:
+			ed.selectAll()
+		}
+		return
+	}
+
+	if ed.selecting {
+		ed.moveTo(indexAt(mouse.X, mouse.Y), true)
+	}
+}
+
+//clipboard returns the text from the clipboard
+func (ed *textEdit) clipboard(gui *GUI) []rune {
+	str, _ := gui.host.GetClipboardString()
+	return []rune(str)
+}
+
+//paste inserts the pasted runes as one change
+func (ed *textEdit) paste(runes []rune) {
+	if len(runes) > 0 {
+		ed.record(editOther)
+		ed.insert(runes)
+	}
+}
+
+//drawSelection draws the highlight of the runes [start, end) in the line of the text
+//at pos and the runes over it
+func (ed *textEdit) drawSelection(gui *GUI, font *Font, pos mgl32.Vec2, h float32, line textLine, z uint8) {
+	start, end := ed.selection()
+	if start < line.start {
+		start = line.start
+	}
+	if end > line.end {
+		end = line.end
+	}
+	if start >= end {
+		return
+	}
+
+	x := pos[0] + ed.width(font, line.start, start)
+	w := ed.width(font, start, end)
+
+	r := Rect{TLX: x, TLY: pos[1], BRX: x + w, BRY: pos[1] - h, W: w, H: h}
+	style := DefaultTextSelectionStyle
+
+	cmd := gui.GetLastCmd(z)
+	cmd.DrawFilledRect(r, style.BackgroundColor, defaultTextureSampler, whitePixelUv)
+
+	gui.drawText(font, mgl32.Vec2{x, pos[1]}, style.TextColor, ed.display(start, end), z)
+}
+
+//width returns the rendered width of the runes [start, end)
+func (ed *textEdit) width(font *Font, start, end int) (w float32) {
+	for i := start; i < end; i++ {
+		w += font.RuneAdvance(ed.glyph(i))
+	}
+	return
+}
+
+//glyph returns the rune rendered at the index
+func (ed *textEdit) glyph(i int) rune {
+	if ed.mask != 0 {
+		return ed.mask
+	}
+	return ed.runes[i]
+}
+
+//display returns the rendered text of the runes [start, end)
+func (ed *textEdit) display(start, end int) string {
+	if ed.mask != 0 {
+		return strings.Repeat(string(ed.mask), end-start)
+	}
+	return string(ed.runes[start:end])
+}
+
+//drawCaret draws the blinking vertical line of the caret at x from the top to the bottom
+func (ed *textEdit) drawCaret(gui *GUI, x, top, bottom float32, color mgl32.Vec4, z uint8) {
+	ed.cursorTimer += gui.dt
+	if ed.cursorTimer < 0.6 {
+		r := Rect{TLX: x + 1, TLY: top, BRX: x + caretWidth, BRY: bottom}
+		r.W = r.BRX - r.TLX
+		r.H = r.TLY - r.BRY
+
+		cmd := gui.GetLastCmd(z)
+		cmd.DrawFilledRect(r, color, defaultTextureSampler, whitePixelUv)
+	}
+	if ed.cursorTimer > 1 {
+		ed.cursorTimer = 0
+	}
+}
+
+// NewInput creates an editbox control that changes the value string.
+func (c *Container) NewInput(id string, text *string, f Callback) *Widget {
+	return c.NewInputAdv(id, text, InputOptions{}, f)
+}
+
+//InputOptions restricts the text of the input and changes the way it is shown
+type InputOptions struct {
+	MaxLength int               //maximal number of the runes, 0 is unlimited
+	Filter    func(rune) bool   //accepts the typed and pasted runes, ex: FilterDigits
+	Validator func(string) bool //marks the input invalid if it returns false
+
+	Password    bool
+	Mask        rune   //rendered instead of every rune in the password mode, '*' by default
+	Placeholder string //dimmed text shown while the value is empty
+}
+
+//FilterDigits accepts the decimal digits only
+func FilterDigits(ch rune) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+//FilterHex accepts the hexadecimal digits only
+func FilterHex(ch rune) bool {
+	return FilterDigits(ch) || ch >= 'a' && ch <= 'f' || ch >= 'A' && ch <= 'F'
+}
+
+//NewInputAdv creates an editbox control that changes the value string with the options
+func (c *Container) NewInputAdv(id string, text *string, opts InputOptions, f Callback) *Widget {
+	wgt := &Widget{
+		ID:          id,
+		Text:        *text,
+		Font:        c.gui.GetFont(c.FontName),
+		Style:       DefaultInputStyle,
+		StyleActive: DefaultInputStyleActive,
+		Container:   c,
+		Layout:      NewLayout("", "", "100%", "28px", c.Layout),
+		OnKeyEnter:  f,
+		Focusable:   true,
+	}
+
+	inp := &input{textEdit: newTextEdit(text), InputOptions: opts}
+	if opts.Password {
+		inp.mask = opts.Mask
+		if inp.mask == 0 {
+			inp.mask = '*'
+		}
+		wgt.Text = inp.display(0, len(inp.runes))
+	}
+	inp.validate()
+
+	wgt.ConstructorData = inp
+	wgt.Constructor = wgt.inputConstructor
+
+	c.addWidget(wgt)
+	return wgt
+}
+
+type input struct {
+	textEdit
+	InputOptions
+
+	offset int //first visible rune, the text is scrolled to keep the caret visible
+	valid  bool
+}
+
+func (wgt *Widget) inputConstructor() (style Style) {
+	return wgt.ConstructorData.(*input).construct(wgt)
+}
+
+//construct handles the mouse and the keys and draws the input state over the widget,
+//it is reused by the widgets which edit their value as text
+func (inp *input) construct(wgt *Widget) (style Style) {
+	gui := wgt.Container.gui
+	font := wgt.Font
+
+	inp.sync()
+	wgt.Text = inp.display(0, len(inp.runes))
+
+	_, h, _ := font.GetRenderSize(wgt.Text)
+	pos := wgt.Layout.GetTextPosLeft(h)
+
+	inp.dragSelection(wgt, func(x, y float32) int {
+		return inp.indexAt(font, x-pos[0])
+	})
+	if inp.selecting {
+		gui.ActiveWidget = wgt
+	}
+
+	click, onWidget := wgt.IsClick()
+	if click {
+		if onWidget {
+			gui.ActiveWidget = wgt
+		} else if gui.ActiveWidget == wgt {
+			gui.ActiveWidget = nil
+		}
+	}
+
+	active := gui.ActiveWidget == wgt && !click
+	if active {
+		inp.handleKeys(wgt)
+
+		inp.commit()
+		wgt.Text = inp.display(0, len(inp.runes))
+	}
+	inp.validate()
+
+	r := wgt.Layout.GetContentRect()
+	inp.scrollToCaret(font, r.W)
+	wgt.textOffset = inp.offset
+
+	gui.pushClip(r)
+
+	if len(inp.runes) == 0 && inp.Placeholder != "" {
+		gui.drawText(font, pos, DefaultInputStylePlaceholder.TextColor, inp.Placeholder, wgt.Z+1)
+	}
+
+	if active {
+		inp.drawSelection(gui, font, pos, h, textLine{inp.offset, len(inp.runes)}, wgt.Z+1)
+
+		//render text cursor vertical line
+		lenText := inp.width(font, inp.offset, inp.cursor)
+		inp.drawCaret(gui, r.TLX+lenText, r.TLY, r.BRY, wgt.StyleActive.TextColor, wgt.Z+1)
+	}
+
+	gui.popClip()
+
+	switch {
+	case !inp.valid:
+		return DefaultInputStyleInvalid
+	case active:
+		return wgt.StyleActive
+	}
+	return
+}
+
+func (inp *input) handleKeys(wgt *Widget) {
+	gui := wgt.Container.gui
+
+	// grab the key events
+	for _, k := range gui.Keys.GetKeys() {
+
+		switch k.KeyCode {
+		case KeyRight:
+			gui.Keys.use(KeyRight)
+			if k.Ctrl() {
+				inp.moveWord(1, k.Shift())
+			} else {
+				inp.moveBy(1, k.Shift())
+			}
+		case KeyLeft:
+			gui.Keys.use(KeyLeft)
+			if k.Ctrl() {
+				inp.moveWord(-1, k.Shift())
+			} else {
+				inp.moveBy(-1, k.Shift())
+			}
+		case KeyBackspace:
+			inp.backspace(k.Ctrl())
+		case KeyDelete:
+			inp.delete(k.Ctrl())
+		case KeyEnter, KeyKPEnter:
+			if wgt.OnKeyEnter != nil {
+				wgt.OnKeyEnter(wgt)
+			}
+			gui.ActiveWidget = nil
+		case KeyEscape:
+			gui.ActiveWidget = nil
+		case KeyEnd:
+			inp.moveTo(len(inp.runes), k.Shift())
+		case KeyHome:
+			inp.moveTo(0, k.Shift())
+		case KeyA:
+			if k.Ctrl() {
+				inp.selectAll()
+			}
+		case KeyC:
+			if k.Ctrl() {
+				inp.copy(gui)
+			}
+		case KeyX:
+			if k.Ctrl() {
+				inp.cut(gui)
+			}
+		case KeyV:
+			if k.Ctrl() {
+				inp.paste(inp.accept(inp.clipboard(gui)))
+			}
+		case KeyZ:
+			if k.HasMods(ModControl | ModShift) {
+				inp.redoEdit()
+			} else if k.Ctrl() {
+				inp.undoEdit()
+			}
+		case KeyY:
+			if k.Ctrl() {
+				inp.redoEdit()
+			}
+		}
+	}
+
+	inp.typeRunes(inp.accept(gui.Keys.GetRunes()))
+}
+
+//accept returns the runes passed by the filter which fit in the max length,
+//the line breaks are dropped
+func (inp *input) accept(runes []rune) []rune {
+	var accepted []rune
+	for _, ch := range runes {
+		if ch == '\n' || ch == '\r' || inp.Filter != nil && !inp.Filter(ch) {
+			continue
+		}
+		accepted = append(accepted, ch)
+	}
+
+	if inp.MaxLength > 0 {
+		start, end := inp.selection()
+		free := inp.MaxLength - len(inp.runes) + end - start
+		if free < 0 {
+			free = 0
+		}
+		if len(accepted) > free {
+			accepted = accepted[:free]
+		}
+	}
+
+	return accepted
+}
+
+func (inp *input) validate() {
+	inp.valid = inp.Validator == nil || inp.Validator(*inp.value)
+}
+
+//IsValid returns false if the value of the input is rejected by its validator
+func (wgt *Widget) IsValid() bool {
+	if inp, ok := wgt.ConstructorData.(*input); ok {
+		return inp.valid
+	}
+	return true
+}
+
+//indexAt returns the caret position nearest to x from the start of the visible text
+func (inp *input) indexAt(font *Font, x float32) int {
+	if x < 0 {
+		return inp.offset - 1
+	}
+
+	var w float32
+	for i := inp.offset; i < len(inp.runes); i++ {
+		adv := font.RuneAdvance(inp.glyph(i))
+		if x < w+adv/2 {
+			return i
+		}
+		w += adv
+	}
+
+	return len(inp.runes)
+}
+
+//scrollToCaret changes the offset to keep the caret inside of the width,
+//the free space at the right is filled by the text scrolled back
+func (inp *input) scrollToCaret(font *Font, width float32) {
+	if inp.offset > inp.cursor {
+		inp.offset = inp.cursor
+	}
+	for inp.offset < inp.cursor && inp.width(font, inp.offset, inp.cursor)+caretWidth > width {
+		inp.offset++
+	}
+	for inp.offset > 0 && inp.width(font, inp.offset-1, len(inp.runes))+caretWidth <= width {
+		inp.offset--
+	}
+}
+
+//ClearHistory drops the undo history of the input or the text area,
+//it should be called when the bound value is replaced from code
+func (wgt *Widget) ClearHistory() {
+	switch ed := wgt.ConstructorData.(type) {
+	case *input:
+		ed.sync()
+		ed.clearHistory()
+	case *textArea:
+		ed.sync()
+		ed.clearHistory()
+	}
+}
+
+//
+// TextArea ===================================
+//
+
+//NewTextArea creates multi-line text editor that changes the value string,
+//the text is wrapped to the widget width and scrolled inside of it
+func (c *Container) NewTextArea(id string, text *string, f Callback) *Widget {
+	wgt := &Widget{
+		ID:          id,
+		Font:        c.gui.GetFont(c.FontName),
+		Style:       DefaultInputStyle,
+		StyleActive: DefaultInputStyleActive,
+		Container:   c,
+		Layout:      NewLayout("", "", "100%", "120px", c.Layout),
+		OnActive:    f,
+		Focusable:   true,
+		scrollable:  true,
+	}
+	wgt.ConstructorData = &textArea{textEdit: newTextEdit(text), goalX: -1}
+	wgt.Constructor = wgt.textAreaConstructor
+
+	c.addWidget(wgt)
+	return wgt
+}
+
+type textArea struct {
+	textEdit
+
+	lines  []textLine
+	scroll float32 //vertical scroll of the text in pixels
+	goalX  float32 //caret x kept by up and down keys, negative if it is not set
+
+	//atLineEnd keeps the caret at the break of the word broken without the space
+	//at the end of the upper line instead of the start of the next one
+	atLineEnd bool
+}
+
+//textLine is a wrapped line of the text, runes [start, end) without the line break
+type textLine struct {
+	start, end int
+}
+
+func (wgt *Widget) textAreaConstructor() (style Style) {
+	gui := wgt.Container.gui
+	ta := wgt.ConstructorData.(*textArea)
+	font := wgt.Font
+
+	r := wgt.Layout.GetContentRect()
+	_, lineH, _ := font.GetRenderSize("`j*}")
+
+	ta.sync()
+	ta.lines = wrapText(font, ta.runes, r.W)
+
+	active := gui.ActiveWidget == wgt
+	caretMoved := false
+
+	ta.dragSelection(wgt, func(x, y float32) int {
+		line := ta.lineAt(r.TLY+ta.scroll-y, lineH)
+		i := ta.indexAt(font, line, x-r.TLX)
+		ta.atLineEnd = i == ta.lineEnd(line)
+		return i
+	})
+	if ta.selecting {
+		gui.ActiveWidget = wgt
+		active = true
+		ta.goalX = -1
+		caretMoved = true
+	}
+
+	click, onWidget := wgt.IsClick()
+	if click {
+		if onWidget {
+			gui.ActiveWidget = wgt
+			active = true
+		} else if active {
+			gui.ActiveWidget = nil
+			active = false
+		}
+	}
+
+	if wgt.IsHover() {
+		ta.scroll -= gui.Mouse.ScrollDelta
+	}
+
+	if active {
+		pageLines := int(r.H / lineH)
+		if pageLines < 1 {
+			pageLines = 1
+		}
+
+		for _, k := range gui.Keys.GetKeys() {
+			vertical := false
+			ta.atLineEnd = false
+
+			switch k.KeyCode {
+			case KeyRight:
+				gui.Keys.use(KeyRight)
+				if k.Ctrl() {
+					ta.moveWord(1, k.Shift())
+				} else {
+					ta.moveBy(1, k.Shift())
+				}
+			case KeyLeft:
+				gui.Keys.use(KeyLeft)
+				if k.Ctrl() {
+					ta.moveWord(-1, k.Shift())
+				} else {
+					ta.moveBy(-1, k.Shift())
+				}
+			case KeyUp:
+				gui.Keys.use(KeyUp)
+				ta.moveLines(font, -1, k.Shift())
+				vertical = true
+			case KeyDown:
+				gui.Keys.use(KeyDown)
+				ta.moveLines(font, 1, k.Shift())
+				vertical = true
+			case KeyPageUp:
+				ta.moveLines(font, -pageLines, k.Shift())
+				vertical = true
+			case KeyPageDown:
+				ta.moveLines(font, pageLines, k.Shift())
+				vertical = true
+			case KeyHome:
+				if k.Ctrl() {
+					ta.moveTo(0, k.Shift())
+				} else {
+					ta.moveTo(ta.lines[ta.caretLine()].start, k.Shift())
+				}
+			case KeyEnd:
+				if k.Ctrl() {
+					ta.moveTo(len(ta.runes), k.Shift())
+				} else {
+					ta.moveTo(ta.lineEnd(ta.caretLine()), k.Shift())
+					ta.atLineEnd = true
+				}
+			case KeyBackspace:
+				ta.backspace(k.Ctrl())
+			case KeyDelete:
+				ta.delete(k.Ctrl())
+			case KeyEnter, KeyKPEnter:
+				ta.typeRunes([]rune{'\n'})
+			case KeyEscape:
+				gui.ActiveWidget = nil
+				active = false
+			case KeyA:
+				if k.Ctrl() {
+					ta.selectAll()
+				}
+			case KeyC:
+				if k.Ctrl() {
+					ta.copy(gui)
+				}
+			case KeyX:
+				if k.Ctrl() {
+					ta.cut(gui)
+				}
+			case KeyV:
+				if k.Ctrl() {
+					ta.paste(ta.clipboard(gui))
+				}
+			case KeyZ:
+				if k.HasMods(ModControl | ModShift) {
+					ta.redoEdit()
+				} else if k.Ctrl() {
+					ta.undoEdit()
+				}
+			case KeyY:
+				if k.Ctrl() {
+					ta.redoEdit()
+				}
+			}
+
+			if !vertical {
+				ta.goalX = -1
+			}
+			caretMoved = true
+
+			//the lines are needed by the next key
+			ta.lines = wrapText(font, ta.runes, r.W)
+		}
+
+		if runes := gui.Keys.GetRunes(); len(runes) > 0 {
+			ta.typeRunes(runes)
+			ta.goalX = -1
+			ta.atLineEnd = false
+			caretMoved = true
+		}
+
+		if *ta.value != string(ta.runes) {
+			ta.commit()
+			ta.lines = wrapText(font, ta.runes, r.W)
+			if wgt.OnActive != nil {
+				wgt.OnActive(wgt)
+			}
+		}
+	}
+
+	//keep the caret visible
+	line := ta.caretLine()
+	if caretMoved {
+		top := float32(line) * lineH
+		if top+lineH > ta.scroll+r.H {
+			ta.scroll = top + lineH - r.H
+		}
+		if top < ta.scroll {
+			ta.scroll = top
+		}
+	}
+
+	maxScroll := float32(len(ta.lines))*lineH - r.H
+	ta.scroll = clampFloat(ta.scroll, 0, maxScroll)
+	if maxScroll < 0 {
+		ta.scroll = 0
+	}
+
+	style = wgt.Style
+	if active {
+		style = wgt.StyleActive
+	}
+
+	gui.pushClip(r)
+
+	first := int(ta.scroll / lineH)
+	for i := first; i < len(ta.lines) && float32(i)*lineH < ta.scroll+r.H; i++ {
+		l := ta.lines[i]
+		if l.end <= l.start {
+			continue
+		}
+
+		pos := mgl32.Vec2{r.TLX, r.TLY + ta.scroll - float32(i)*lineH}
+		gui.drawText(font, pos, style.TextColor, string(ta.runes[l.start:l.end]), wgt.Z+1)
+
+		if active {
+			ta.drawSelection(gui, font, pos, lineH, l, wgt.Z+1)
+		}
+	}
+
+	if active {
+		top := r.TLY + ta.scroll - float32(line)*lineH
+		x := r.TLX + ta.caretX(font)
+		ta.drawCaret(gui, x, top, top-lineH, style.TextColor, wgt.Z+1)
+	}
+
+	gui.popClip()
+
+	return
+}
+
+//lineOf returns the line containing the rune index, the index at a soft break belongs to the next line
+func (ta *textArea) lineOf(index int) int {
+	for i := len(ta.lines) - 1; i > 0; i-- {
+		if ta.lines[i].start <= index {
+			return i
+		}
+	}
+	return 0
+}
+
+//caretLine returns the line the caret is drawn on
+func (ta *textArea) caretLine() int {
+	line := ta.lineOf(ta.cursor)
+	if ta.atLineEnd && line > 0 && ta.lines[line].start == ta.cursor && ta.lineEnd(line-1) == ta.cursor {
+		line--
+	}
+	return line
+}
+
+//lineAt returns the line at the distance from the top of the text
+func (ta *textArea) lineAt(y, lineH float32) int {
+	i := int(y / lineH)
+	if y < 0 || i < 0 {
+		return 0
+	}
+	if i >= len(ta.lines) {
+		return len(ta.lines) - 1
+	}
+	return i
+}
+
+//lineEnd returns the last caret position of the line, the caret at the end of
+//a line wrapped at the space stays before the space to be on this line,
+//a word broken without the space has no such space, the caret is after its last rune
+func (ta *textArea) lineEnd(i int) int {
+	l := ta.lines[i]
+	if i+1 < len(ta.lines) && ta.lines[i+1].start == l.end && l.end > l.start && ta.runes[l.end-1] == ' ' {
+		return l.end - 1
+	}
+	return l.end
+}
+
+//indexAt returns the caret position in the line nearest to x
+func (ta *textArea) indexAt(font *Font, line int, x float32) int {
+	l := ta.lines[line]
+	end := ta.lineEnd(line)
+
+	var w float32
+	for i := l.start; i < end; i++ {
+		adv := font.RuneAdvance(ta.runes[i])
+		if x < w+adv/2 {
+			return i
+		}
+		w += adv
+	}
+
+	return end
+}
+
+//caretX returns the distance from the line start to the caret
+func (ta *textArea) caretX(font *Font) float32 {
+	return ta.width(font, ta.lines[ta.caretLine()].start, ta.cursor)
+}
+
+//moveLines moves the caret by n lines keeping its horizontal position
+func (ta *textArea) moveLines(font *Font, n int, extend bool) {
+	if ta.goalX < 0 {
+		ta.goalX = ta.caretX(font)
+	}
+
+	line := ta.caretLine() + n
+	switch {
+	case line < 0:
+		ta.moveTo(0, extend)
+	case line >= len(ta.lines):
+		ta.moveTo(len(ta.runes), extend)
+	default:
+		i := ta.indexAt(font, line, ta.goalX)
+		ta.moveTo(i, extend)
+		ta.atLineEnd = i == ta.lineEnd(line)
+	}
+}
+
+//wrapText splits the text to lines by the line breaks and by the width,
+//long lines are broken after the last space if there is one
+func wrapText(font *Font, runes []rune, width float32) (lines []textLine) {
+	start := 0
+	lastSpace := -1
+
+	var w float32
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+		if ch == '\n' {
+			lines = append(lines, textLine{start, i})
+			start = i + 1
+			lastSpace = -1
+			w = 0
+			continue
+		}
+
+		adv := font.RuneAdvance(ch)
+		if w+adv > width && i > start {
+			brk := i
+			if lastSpace >= start {
+				brk = lastSpace + 1
+			}
+			lines = append(lines, textLine{start, brk})
+			start = brk
+			lastSpace = -1
+
+			w = 0
+			for j := start; j < i; j++ {
+				w += font.RuneAdvance(runes[j])
+			}
+		}
+
+		if ch == ' ' {
+			lastSpace = i
+		}
+		w += adv
+	}
+
+	return append(lines, textLine{start, len(runes)})
+}
@@ -0,0 +1,313 @@
+package fizzgui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTextAreaLineEnd(t *testing.T) {
+	g, _, _ := newTestGUI(t, 400, 300)
+	font := g.GetFont("Default")
+
+	ta := &textArea{textEdit: newTextEdit(new(string)), goalX: -1}
+
+	//the long word is broken without the space, the words are wrapped at the space
+	ta.runes = []rune("aaaaaaaaaaaaaaaaaaaa aaaaaaa aa")
+	width := font.RuneAdvance('a') * 8.5
+	ta.lines = wrapText(font, ta.runes, width)
+	if len(ta.lines) < 3 {
+		t.Fatal("text is not wrapped", ta.lines)
+	}
+
+	l := ta.lines[0]
+	if end := ta.lineEnd(0); end != l.end {
+		t.Fatalf("end of the broken word line is %d, want %d", end, l.end)
+	}
+
+	spaced := -1
+	for i, l := range ta.lines[:len(ta.lines)-1] {
+		if ta.runes[l.end-1] == ' ' {
+			spaced = i
+			break
+		}
+	}
+	if spaced < 0 {
+		t.Fatal("no line is wrapped at the space", ta.lines)
+	}
+	if end, l := ta.lineEnd(spaced), ta.lines[spaced]; end != l.end-1 {
+		t.Fatalf("end of the line wrapped at the space is %d, want %d", end, l.end-1)
+	}
+
+	//the caret at the end of the broken line stays on it
+	ta.cursor = ta.lineEnd(0)
+	ta.atLineEnd = true
+	if line := ta.caretLine(); line != 0 {
+		t.Fatal("caret is drawn on the line", line)
+	}
+	if x := ta.caretX(font); x != ta.width(font, 0, ta.cursor) {
+		t.Fatal("caret is not after the last rune of the line", x)
+	}
+}
+
+func TestWordBoundaries(t *testing.T) {
+	tests := []struct {
+		text        string
+		left, right int //word starts from the end and from the start of the text
+	}{
+		{"hello world", 6, 6},
+		{"  foo.bar", 6, 2},
+		{"don't stop", 6, 6},
+		{"pi = 3.14", 5, 3},
+		{"café au", 6, 6},
+		{"'quoted'", 7, 1},
+	}
+
+	for _, tt := range tests {
+		ed := newTextEdit(&tt.text)
+
+		if i := ed.wordLeft(len(ed.runes)); i != tt.left {
+			t.Errorf("%q: wordLeft from the end is %d, want %d", tt.text, i, tt.left)
+		}
+		if i := ed.wordRight(0); i != tt.right {
+			t.Errorf("%q: wordRight from the start is %d, want %d", tt.text, i, tt.right)
+		}
+	}
+}
+
+func TestUndoCoalescing(t *testing.T) {
+	text := ""
+	ed := newTextEdit(&text)
+
+	check := func(want string) {
+		t.Helper()
+		if got := string(ed.runes); got != want {
+			t.Fatalf("text is %q, want %q", got, want)
+		}
+	}
+
+	//the typing run is undone at once
+	for _, ch := range "abc" {
+		ed.typeRunes([]rune{ch})
+	}
+	check("abc")
+
+	//the deleting run is the next step
+	ed.backspace(false)
+	ed.backspace(false)
+	check("a")
+
+	//the caret move ends the run
+	ed.moveTo(0, false)
+	ed.typeRunes([]rune("x"))
+	ed.typeRunes([]rune("y"))
+	check("xya")
+
+	ed.undoEdit()
+	check("a")
+	ed.undoEdit()
+	check("abc")
+	ed.undoEdit()
+	check("")
+	ed.undoEdit()
+	check("")
+
+	ed.redoEdit()
+	check("abc")
+	if ed.cursor != 3 {
+		t.Fatal("caret is not restored", ed.cursor)
+	}
+
+	//the new change drops the redo history
+	ed.typeRunes([]rune("d"))
+	ed.redoEdit()
+	check("abcd")
+}
+
+func TestWrapText(t *testing.T) {
+	g, _, _ := newTestGUI(t, 400, 300)
+	font := g.GetFont("Default")
+	a, space := font.RuneAdvance('a'), font.RuneAdvance(' ')
+
+	tests := []struct {
+		text  string
+		width float32
+		lines []textLine
+	}{
+		{"", 100, []textLine{{0, 0}}},
+		{"ab\ncd", 100, []textLine{{0, 2}, {3, 5}}},
+		{"ab\n", 100, []textLine{{0, 2}, {3, 3}}},
+		{"aaaa aaaa aaaa", a*6 + space, []textLine{{0, 5}, {5, 10}, {10, 14}}},
+		{"aaaaaaaaaa", a * 4.5, []textLine{{0, 4}, {4, 8}, {8, 10}}},
+		{"aa aaaaaaaaa", a * 4.5, []textLine{{0, 3}, {3, 7}, {7, 11}, {11, 12}}},
+	}
+
+	for _, tt := range tests {
+		lines := wrapText(font, []rune(tt.text), tt.width)
+		if !reflect.DeepEqual(lines, tt.lines) {
+			t.Errorf("%q: lines are %v, want %v", tt.text, lines, tt.lines)
+		}
+	}
+}
+
+func TestInputSelectionCopy(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+
+	text := "hello world"
+	in := c.NewInput("in", &text, nil)
+	click(g, host, in)
+
+	host.TypeKey(KeyHome, 0)
+	host.TypeKey(KeyRight, ModShift|ModControl)
+	host.TypeKey(KeyC, ModControl)
+	g.Construct()
+	if host.Clipboard != "hello " {
+		t.Fatalf("copied %q", host.Clipboard)
+	}
+
+	host.TypeKey(KeyEnd, ModShift)
+	host.TypeKey(KeyX, ModControl)
+	g.Construct()
+	if text != "" || host.Clipboard != "hello world" {
+		t.Fatalf("cut %q, text is %q", host.Clipboard, text)
+	}
+
+	host.TypeKey(KeyV, ModControl)
+	host.TypeKey(KeyA, ModControl)
+	g.Construct()
+	ed := in.ConstructorData.(*input)
+	if start, end := ed.selection(); text != "hello world" || start != 0 || end != len(ed.runes) {
+		t.Fatalf("text is %q, selection is %d:%d", text, start, end)
+	}
+}
+
+func TestInputClickSelection(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+
+	text := "hello world"
+	in := c.NewInput("in", &text, nil)
+	g.Construct()
+	g.Construct()
+
+	ed := in.ConstructorData.(*input)
+	font := in.Font
+	_, h, _ := font.GetRenderSize(in.Text)
+	pos := in.Layout.GetTextPosLeft(h)
+	y := in.Layout.GetBackgroundRect().TLY - in.Layout.GetBackgroundRect().H/2
+
+	clickAt := func(x float32) {
+		moveCursor(g, host, pos[0]+x, y)
+		host.PressButton(0)
+		g.Construct()
+		host.ReleaseButton(0)
+		g.Construct()
+	}
+	selected := func() string {
+		start, end := ed.selection()
+		return string(ed.runes[start:end])
+	}
+
+	//the caret is placed at the nearest rune boundary
+	clickAt(ed.width(font, 0, 2) + 1)
+	if ed.cursor != 2 || ed.hasSelection() {
+		t.Fatal("caret is placed at", ed.cursor)
+	}
+
+	//the double click in the middle of the word selects it, the third one selects all
+	time.Sleep(time.Duration(g.Mouse.doubleClickThreshold*float64(time.Second)) + 10*time.Millisecond)
+	x := ed.width(font, 0, 8)
+	clickAt(x)
+	clickAt(x)
+	if s := selected(); s != "world" {
+		t.Fatalf("double click selects %q", s)
+	}
+	clickAt(x)
+	if s := selected(); s != text {
+		t.Fatalf("triple click selects %q", s)
+	}
+}
+
+func TestInputScrollToCaret(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+
+	text := "short"
+	in := c.NewInput("in", &text, nil)
+	in.Layout.SetWidth("150px")
+	g.Construct()
+	g.Focus(in)
+
+	host.TypeKey(KeyEnd, 0)
+	host.TypeString("/a/very/long/path/to/some/file/on/disk.txt")
+	g.Construct()
+
+	ed := in.ConstructorData.(*input)
+	r := in.Layout.GetContentRect()
+	if ed.offset == 0 || in.textOffset != ed.offset {
+		t.Fatal("long text is not scrolled", ed.offset, in.textOffset)
+	}
+	if ed.width(in.Font, ed.offset, ed.cursor)+caretWidth > r.W {
+		t.Fatal("caret is outside of the input")
+	}
+
+	host.TypeKey(KeyHome, 0)
+	g.Construct()
+	if ed.offset != 0 {
+		t.Fatal("text is not scrolled back to the caret at the start", ed.offset)
+	}
+
+	//the text shortened to the width is not scrolled
+	host.TypeKey(KeyEnd, 0)
+	g.Construct()
+	for i := 0; i < 40; i++ {
+		host.TypeKey(KeyBackspace, 0)
+	}
+	g.Construct()
+	if ed.offset != 0 {
+		t.Fatal("short text is scrolled", ed.offset, text)
+	}
+}
+
+func TestInputOptions(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+
+	num := ""
+	n := c.NewInputAdv("num", &num, InputOptions{MaxLength: 4, Filter: FilterDigits}, nil)
+	pass := "secret"
+	p := c.NewInputAdv("pass", &pass, InputOptions{Password: true}, nil)
+	g.Construct()
+
+	//the filtered runes are dropped, the text is cut to the max length
+	g.Focus(n)
+	host.TypeString("12a3x456")
+	g.Construct()
+	if num != "1234" {
+		t.Fatalf("typed text is %q", num)
+	}
+
+	host.Clipboard = "99"
+	host.TypeKey(KeyBackspace, 0)
+	host.TypeKey(KeyV, ModControl)
+	g.Construct()
+	if num != "1239" {
+		t.Fatalf("pasted text is %q", num)
+	}
+
+	//the password is masked and not copied
+	g.Focus(p)
+	host.TypeKey(KeyEnd, 0)
+	host.TypeString("!")
+	g.Construct()
+	host.TypeKey(KeyA, ModControl)
+	host.TypeKey(KeyC, ModControl)
+	g.Construct()
+	if pass != "secret!" || p.Text != "*******" {
+		t.Fatalf("password is %q, shown as %q", pass, p.Text)
+	}
+	if host.Clipboard != "99" {
+		t.Fatalf("password is copied %q", host.Clipboard)
+	}
+}
@@ -1,86 +1,169 @@
 package fizzgui
 
-import (
-	"github.com/go-gl/glfw/v3.2/glfw"
-)
+type keyboard struct {
+	//events of the current frame
+	events []KeyEvent
+	keys   []KeyEvent
+	runes  []rune
 
-var Keys *keyboard
+	//events received since the last Update
+	pendingEvents []KeyEvent
 
-type keyboard struct {
-	keys  []KeyEvent
-	runes []rune
+	//keys held down and the modifiers of the last event
+	down map[Key]bool
+	mods ModifierKey
 
-	listening bool
+	//keys handled by the widgets in the current frame, they do not move the focus
+	used map[Key]bool
 
-	prevKeyCallback      glfw.KeyCallback
-	prevCharModsCallback glfw.CharModsCallback
+	//capture reports whether the received events are handled by the gui
+	capture func() bool
 }
 
-func initKeyboard(window *glfw.Window) {
-	Keys = new(keyboard)
-	Keys.prevKeyCallback = window.SetKeyCallback(Keys.charKeyCallback)
-	Keys.prevCharModsCallback = window.SetCharModsCallback(Keys.charModsCallback)
-}
+func newKeyboard(host Host, capture func() bool) *keyboard {
+	kbrd := &keyboard{down: make(map[Key]bool), used: make(map[Key]bool), capture: capture}
+	host.SetKeyCallback(kbrd.charKeyCallback)
+	host.SetCharCallback(kbrd.charModsCallback)
 
-func (kbrd *keyboard) DisableListening() {
-	kbrd.listening = false
+	return kbrd
 }
 
-func (kbrd *keyboard) GetKeys() (keys []KeyEvent) {
-	kbrd.listening = true
-	keys = kbrd.keys
+//Update makes the events received since the previous frame current, should be call each frame
+func (kbrd *keyboard) Update() {
+	kbrd.events, kbrd.pendingEvents = kbrd.pendingEvents, kbrd.events[:0]
+
 	kbrd.keys = kbrd.keys[:0]
-	return
+	kbrd.runes = kbrd.runes[:0]
+	for _, k := range kbrd.events {
+		switch {
+		case k.Rune > 0:
+			kbrd.runes = append(kbrd.runes, k.Rune)
+		case k.IsPress():
+			kbrd.keys = append(kbrd.keys, k)
+		}
+	}
+
+	for key := range kbrd.used {
+		delete(kbrd.used, key)
+	}
 }
 
-//KeyEvent contains keyCode, rune and pressed key modifiers
-type KeyEvent struct {
-	KeyCode glfw.Key
+//inject adds the key press to the current frame as if it was pressed by the user
+func (kbrd *keyboard) inject(key Key) {
+	k := KeyEvent{KeyCode: key, Action: Press}
+	kbrd.events = append(kbrd.events, k)
+	kbrd.keys = append(kbrd.keys, k)
+}
 
-	Shift bool
-	Ctrl  bool
-	Alt   bool
-	Super bool
+//use marks the key handled by the focused widget
+func (kbrd *keyboard) use(key Key) {
+	kbrd.used[key] = true
 }
 
-func (kbrd *keyboard) charKeyCallback(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+//GetKeys returns key presses and repeats of the current frame
+func (kbrd *keyboard) GetKeys() []KeyEvent {
+	return kbrd.keys
+}
 
-	if kbrd.listening {
-		if action != glfw.Press && action != glfw.Repeat {
-			return
-		}
+//GetKeyEvents returns all keyboard events of the current frame in the order they are received:
+//presses, repeats, releases and typed characters
+func (kbrd *keyboard) GetKeyEvents() []KeyEvent {
+	return kbrd.events
+}
 
-		k := KeyEvent{
-			Shift:   mods == glfw.ModShift,
-			Ctrl:    mods == glfw.ModControl,
-			Alt:     mods == glfw.ModAlt,
-			Super:   mods == glfw.ModSuper,
-			KeyCode: key,
-		}
+//IsKeyDown reports whether the key is held down
+func (kbrd *keyboard) IsKeyDown(key Key) bool {
+	return kbrd.down[key]
+}
 
-		kbrd.keys = append(kbrd.keys, k)
-	}
+//GetMods returns the modifier keys held down during the last keyboard event
+func (kbrd *keyboard) GetMods() ModifierKey {
+	return kbrd.mods
+}
 
-	if kbrd.prevKeyCallback != nil {
-		kbrd.prevKeyCallback(w, key, scancode, action, mods)
-	}
+//modKeys are the left and the right keys of the modifiers
+var modKeys = [...]struct {
+	mod         ModifierKey
+	left, right Key
+}{
+	{ModShift, KeyLeftShift, KeyRightShift},
+	{ModControl, KeyLeftControl, KeyRightControl},
+	{ModAlt, KeyLeftAlt, KeyRightAlt},
+	{ModSuper, KeyLeftSuper, KeyRightSuper},
 }
 
-func (kbrd *keyboard) GetRunes() (runes []rune) {
-	kbrd.listening = true
-	runes = kbrd.runes
-	kbrd.runes = kbrd.runes[:0]
+//GetHeldMods returns the modifiers of the keys held down now, unlike GetMods it does not keep
+//the modifier reported by its own release event, as X11 does
+func (kbrd *keyboard) GetHeldMods() (mods ModifierKey) {
+	for _, m := range modKeys {
+		if kbrd.down[m.left] || kbrd.down[m.right] {
+			mods |= m.mod
+		}
+	}
 	return
 }
 
-func (kbrd *keyboard) charModsCallback(w *glfw.Window, char rune, mods glfw.ModifierKey) {
+//KeyEvent is the key press, repeat or release, or the typed character if the Rune is set
+type KeyEvent struct {
+	KeyCode  Key //KeyUnknown for the typed characters
+	Scancode int
+	Action   Action
+	Mods     ModifierKey
+	Rune     rune
+}
+
+//HasMods reports whether all of the modifiers are held down, ex: k.HasMods(ModControl|ModShift)
+func (k KeyEvent) HasMods(mods ModifierKey) bool {
+	return k.Mods&mods == mods
+}
+
+//IsPress reports whether the key is pressed or repeated
+func (k KeyEvent) IsPress() bool {
+	return k.Action == Press || k.Action == Repeat
+}
 
-	if kbrd.listening && char > 0 {
+//Shift, Ctrl, Alt and Super report whether the modifier is held down
+func (k KeyEvent) Shift() bool { return k.HasMods(ModShift) }
+func (k KeyEvent) Ctrl() bool  { return k.HasMods(ModControl) }
+func (k KeyEvent) Alt() bool   { return k.HasMods(ModAlt) }
+func (k KeyEvent) Super() bool { return k.HasMods(ModSuper) }
 
-		kbrd.runes = append(kbrd.runes, char)
+func (kbrd *keyboard) charKeyCallback(key Key, scancode int, action Action, mods ModifierKey) bool {
+	kbrd.mods = mods
+	if key != KeyUnknown {
+		kbrd.down[key] = action != Release
 	}
 
-	if kbrd.prevCharModsCallback != nil {
-		kbrd.prevCharModsCallback(w, char, mods)
+	kbrd.pendingEvents = append(kbrd.pendingEvents, KeyEvent{
+		KeyCode:  key,
+		Scancode: scancode,
+		Action:   action,
+		Mods:     mods,
+	})
+
+	//releases are passed on, so the application does not miss the release of the key pressed before the focus
+	if action == Release {
+		return false
 	}
+	return kbrd.capture()
+}
+
+//GetRunes returns characters typed in the current frame
+func (kbrd *keyboard) GetRunes() []rune {
+	return kbrd.runes
+}
+
+func (kbrd *keyboard) charModsCallback(char rune, mods ModifierKey) bool {
+	if char <= 0 {
+		return false
+	}
+
+	kbrd.pendingEvents = append(kbrd.pendingEvents, KeyEvent{
+		KeyCode: KeyUnknown,
+		Action:  Press,
+		Mods:    mods,
+		Rune:    char,
+	})
+
+	return kbrd.capture()
 }
@@ -0,0 +1,98 @@
+package fizzgui
+
+import "testing"
+
+func TestKeyPropagation(t *testing.T) {
+	g, _, _ := newTestGUI(t, 400, 300)
+	g.StopPropagation = true
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+	text := ""
+	in := c.NewInput("in", &text, nil)
+	g.Construct()
+
+	kbrd := g.Keys
+	if kbrd.charKeyCallback(KeyA, 0, Press, 0) {
+		t.Fatal("key is stopped without the focus")
+	}
+
+	g.Focus(in)
+	if !kbrd.charKeyCallback(KeyA, 0, Press, 0) || !kbrd.charKeyCallback(KeyA, 0, Repeat, 0) {
+		t.Fatal("key press is passed on while the input is focused")
+	}
+	if !kbrd.charModsCallback('a', 0) {
+		t.Fatal("char is passed on while the input is focused")
+	}
+	if kbrd.charKeyCallback(KeyA, 0, Release, 0) {
+		t.Fatal("key release is stopped")
+	}
+}
+
+func TestHeldMods(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+
+	host.PressKey(KeyLeftShift, ModShift)
+	host.PressKey(KeyRightControl, ModShift|ModControl)
+	if mods := g.Keys.GetHeldMods(); mods != ModShift|ModControl {
+		t.Fatal("held mods are", mods)
+	}
+
+	//X11 reports the released modifier in the mods of its release event
+	host.ReleaseKey(KeyLeftShift, ModShift|ModControl)
+	host.ReleaseKey(KeyRightControl, ModControl)
+	if mods := g.Keys.GetHeldMods(); mods != 0 {
+		t.Fatal("released mods are held", mods)
+	}
+
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+	btn := c.NewButton("button", nil)
+	clicks := 0
+	btn.OnClick = func(e MouseEvent) {
+		clicks++
+		if e.Mods != 0 {
+			t.Error("click has the released mods", e.Mods)
+		}
+	}
+	g.Construct()
+
+	click(g, host, btn)
+	if clicks != 1 {
+		t.Fatal("button is clicked", clicks)
+	}
+}
+
+func TestKeyEvents(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+
+	host.PressKey(KeyA, ModControl|ModShift)
+	host.RepeatKey(KeyA, ModControl|ModShift)
+	host.TypeString("ä")
+	host.ReleaseKey(KeyA, ModControl|ModShift)
+	g.Keys.charKeyCallback(KeyB, 56, Press, 0)
+	g.Construct()
+
+	events := g.Keys.GetKeyEvents()
+	want := []KeyEvent{
+		{KeyCode: KeyA, Action: Press, Mods: ModControl | ModShift},
+		{KeyCode: KeyA, Action: Repeat, Mods: ModControl | ModShift},
+		{KeyCode: KeyUnknown, Action: Press, Mods: ModControl | ModShift, Rune: 'ä'},
+		{KeyCode: KeyA, Action: Release, Mods: ModControl | ModShift},
+		{KeyCode: KeyB, Scancode: 56, Action: Press},
+	}
+	if len(events) != len(want) {
+		t.Fatal("events are", events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("event %d is %+v, want %+v", i, events[i], want[i])
+		}
+	}
+
+	//the keys are the presses and the repeats, the typed characters are the runes
+	keys := g.Keys.GetKeys()
+	if len(keys) != 3 || !keys[0].HasMods(ModControl|ModShift) || keys[0].Alt() || !keys[1].IsPress() {
+		t.Fatal("keys are", keys)
+	}
+	if runes := g.Keys.GetRunes(); len(runes) != 1 || runes[0] != 'ä' {
+		t.Fatal("runes are", runes)
+	}
+}
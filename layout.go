@@ -131,6 +131,9 @@ type Layout struct {
 
 	Padding Offset
 	Margin  Offset
+
+	//space taken from the content by the owner, ex: scrollbars
+	reserved Offset
 }
 
 func NewLayout(x, y, w, h string, parent *Layout) *Layout {
@@ -248,6 +251,60 @@ func (l *Layout) updateVertPosition(r Rect) {
 	}
 }
 
+//MoveTo places the layout to x,y(top left corner) and writes the position back
+//to its x and y sizes with respect of the align, percent sizes stay percents
+func (l *Layout) MoveTo(x, y float32) {
+	r := l.parent.GetContentRect()
+
+	var xOffset float32
+	switch l.HAlign {
+	case HAlignLeft:
+		xOffset = x - r.TLX
+	case HAlignCenter:
+		xOffset = x - (r.TLX + r.W/2 - l.W/2)
+	case HAlignRight:
+		xOffset = r.BRX - l.W - x
+	}
+
+	var yOffset float32
+	switch l.VAlign {
+	case VAlignTop:
+		yOffset = r.TLY - y
+	case VAlignMiddle:
+		yOffset = r.TLY - r.H/2 + l.H/2 - y
+	case VAlignBottom:
+		yOffset = r.TLY - r.H + l.H - y
+	}
+
+	l.x.value = xOffset
+	if l.x.percent && r.W > 0 {
+		l.x.value = xOffset / r.W
+	}
+	l.y.value = yOffset
+	if l.y.percent && r.H > 0 {
+		l.y.value = yOffset / r.H
+	}
+
+	l.X, l.Y = x, y
+}
+
+//Resize sets width and height of the layout and writes them back to its w and h sizes,
+//percent sizes stay percents
+func (l *Layout) Resize(w, h float32) {
+	r := l.parent.GetContentRect()
+
+	l.w.value = w
+	if l.w.percent && r.W > 0 {
+		l.w.value = w / r.W
+	}
+	l.h.value = h
+	if l.h.percent && r.H > 0 {
+		l.h.value = h / r.H
+	}
+
+	l.W, l.H = w, h
+}
+
 func (l *Layout) AddOffsets(w, h float32) (float32, float32) {
 	w += l.Margin.L + l.Margin.R + l.Padding.L + l.Padding.R
 	h += l.Margin.T + l.Margin.B + l.Padding.T + l.Padding.B
@@ -287,8 +344,7 @@ func (l *Layout) SetCursor(cursor *Cursor) {
 	l.X = cursor.X
 	l.Y = cursor.Y
 
-	r := l.parent.GetContentRect()
-	if l.X+l.W > r.BRX {
+	if l.X+l.W > cursor.rect.BRX {
 		cursor.NextRow()
 		l.X = cursor.X
 		l.Y = cursor.Y
@@ -341,6 +397,36 @@ type Rect struct {
 	H float32
 }
 
+//Intersect returns the area covered by both rects
+func (r Rect) Intersect(o Rect) Rect {
+	if o.TLX > r.TLX {
+		r.TLX = o.TLX
+	}
+	if o.TLY < r.TLY {
+		r.TLY = o.TLY
+	}
+	if o.BRX < r.BRX {
+		r.BRX = o.BRX
+	}
+	if o.BRY > r.BRY {
+		r.BRY = o.BRY
+	}
+
+	r.W = r.BRX - r.TLX
+	r.H = r.TLY - r.BRY
+	if r.W < 0 || r.H < 0 {
+		r.BRX, r.BRY = r.TLX, r.TLY
+		r.W, r.H = 0, 0
+	}
+
+	return r
+}
+
+//ContainsPoint reports whether the point is inside the rect
+func (r Rect) ContainsPoint(x, y float32) bool {
+	return x > r.TLX && x < r.BRX && y < r.TLY && y > r.BRY
+}
+
 func (l *Layout) GetBackgroundRect() (r Rect) {
 	r.TLX = l.X + l.Margin.L
 	r.TLY = l.Y - l.Margin.T
@@ -362,22 +448,17 @@ func (l *Layout) GetBackgroundRect() (r Rect) {
 }
 
 func (l *Layout) GetContentRect() (r Rect) {
-	r.TLX = l.X + l.Margin.L + l.Padding.L
-	r.TLY = l.Y - l.Margin.T - l.Padding.T
+	r.TLX = l.X + l.Margin.L + l.Padding.L + l.reserved.L
+	r.TLY = l.Y - l.Margin.T - l.Padding.T - l.reserved.T
 
-	r.BRX = l.X + l.W - l.Margin.R - l.Padding.R
-	r.BRY = l.Y - l.H + l.Margin.B + l.Padding.B
+	r.BRX = l.X + l.W - l.Margin.R - l.Padding.R - l.reserved.R
+	r.BRY = l.Y - l.H + l.Margin.B + l.Padding.B + l.reserved.B
 
-	r.W = l.W - l.Margin.L - l.Margin.R - l.Padding.L - l.Padding.R
-	r.H = l.H - l.Margin.T - l.Margin.B - l.Padding.T - l.Padding.B
+	r.W = r.BRX - r.TLX
+	r.H = r.TLY - r.BRY
 	return
 }
 
 func (l *Layout) ContainsPoint(x, y float32) bool {
-	r := l.GetBackgroundRect()
-	if x > r.TLX && x < r.BRX && y < r.TLY && y > r.BRY {
-		return true
-	}
-
-	return false
+	return l.GetBackgroundRect().ContainsPoint(x, y)
 }
@@ -0,0 +1,138 @@
+package fizzgui
+
+//MemoryHost is a Host without a real window, the input is fed by its methods.
+//It allows to drive the gui from tests or from machines without a display.
+//Mouse buttons are polled once per Construct, so press and release of one
+//click should be separated by a frame.
+type MemoryHost struct {
+	Width, Height    int
+	CursorX, CursorY float64
+	Clipboard        string
+
+	buttons map[int]bool
+	mods    ModifierKey
+
+	//GamepadAxes and GamepadButtons are the state of the controller if GamepadConnected is set
+	GamepadConnected bool
+	GamepadAxes      []float32
+	GamepadButtons   []bool
+
+	scrollCallback ScrollCallback
+	keyCallback    KeyCallback
+	charCallback   CharCallback
+}
+
+//NewMemoryHost creates host with window size w*h
+func NewMemoryHost(w, h int) *MemoryHost {
+	return &MemoryHost{
+		Width:   w,
+		Height:  h,
+		buttons: make(map[int]bool),
+	}
+}
+
+func (h *MemoryHost) GetSize() (w, ht int) {
+	return h.Width, h.Height
+}
+
+func (h *MemoryHost) GetCursorPos() (x, y float64) {
+	return h.CursorX, h.CursorY
+}
+
+func (h *MemoryHost) GetMouseButton(button int) bool {
+	return h.buttons[button]
+}
+
+func (h *MemoryHost) GetGamepad() (axes []float32, buttons []bool, ok bool) {
+	return h.GamepadAxes, h.GamepadButtons, h.GamepadConnected
+}
+
+func (h *MemoryHost) GetClipboardString() (string, error) {
+	return h.Clipboard, nil
+}
+
+func (h *MemoryHost) SetClipboardString(s string) {
+	h.Clipboard = s
+}
+
+func (h *MemoryHost) SetScrollCallback(f ScrollCallback) {
+	h.scrollCallback = f
+}
+
+func (h *MemoryHost) SetKeyCallback(f KeyCallback) {
+	h.keyCallback = f
+}
+
+func (h *MemoryHost) SetCharCallback(f CharCallback) {
+	h.charCallback = f
+}
+
+//Resize the window
+func (h *MemoryHost) Resize(w, ht int) {
+	h.Width = w
+	h.Height = ht
+}
+
+//MoveCursor to x,y, top left corner of the window is 0,0
+func (h *MemoryHost) MoveCursor(x, y float64) {
+	h.CursorX = x
+	h.CursorY = y
+}
+
+//PressButton holds down the mouse button
+func (h *MemoryHost) PressButton(button int) {
+	h.buttons[button] = true
+}
+
+//ReleaseButton releases the mouse button
+func (h *MemoryHost) ReleaseButton(button int) {
+	h.buttons[button] = false
+}
+
+//Scroll sends the scroll wheel offsets
+func (h *MemoryHost) Scroll(xoff, yoff float64) {
+	if h.scrollCallback != nil {
+		h.scrollCallback(xoff, yoff)
+	}
+}
+
+//PressKey sends a key press with the modifiers held down
+func (h *MemoryHost) PressKey(key Key, mods ModifierKey) {
+	h.mods = mods
+	h.sendKey(key, Press)
+}
+
+//RepeatKey sends a key repeat as if the key is held down
+func (h *MemoryHost) RepeatKey(key Key, mods ModifierKey) {
+	h.mods = mods
+	h.sendKey(key, Repeat)
+}
+
+//ReleaseKey sends a key release
+func (h *MemoryHost) ReleaseKey(key Key, mods ModifierKey) {
+	h.mods = mods
+	h.sendKey(key, Release)
+}
+
+//TypeKey presses and releases the key
+func (h *MemoryHost) TypeKey(key Key, mods ModifierKey) {
+	h.PressKey(key, mods)
+	h.ReleaseKey(key, mods)
+}
+
+//TypeString sends each rune of the string as typed characters
+func (h *MemoryHost) TypeString(s string) {
+	if h.charCallback == nil {
+		return
+	}
+
+	for _, char := range s {
+		h.charCallback(char, h.mods)
+	}
+}
+
+func (h *MemoryHost) sendKey(key Key, action Action) {
+	if h.keyCallback != nil {
+		h.keyCallback(key, 0, action, h.mods)
+	}
+}
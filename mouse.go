@@ -6,7 +6,6 @@ package fizzgui
 import (
 	"time"
 
-	"github.com/go-gl/glfw/v3.2/glfw"
 	"github.com/go-gl/mathgl/mgl32"
 )
 
@@ -18,10 +17,8 @@ const (
 	MouseDoubleClick
 )
 
-var Mouse *mouse
-
 type mouse struct {
-	window *glfw.Window
+	host Host
 
 	frameTime time.Time
 	dt        float32
@@ -29,32 +26,41 @@ type mouse struct {
 	X float32
 	Y float32
 
-	prevScrollCallback glfw.ScrollCallback
-	ScrollSpeed        float32
-	ScrollDelta        float32
+	ScrollSpeed  float32
+	ScrollDelta  float32 //vertical scroll of the current frame
+	ScrollDeltaX float32 //horizontal scroll of the current frame
+
+	//scroll received since the last Update
+	scrollPending  float32
+	scrollPendingX float32
 
 	doubleClickThreshold float64
 
 	buttonsTracker map[int]mouseButtonData
+
+	//capture reports whether the scroll is handled by the gui
+	capture func() bool
 }
 
-func initMouse(window *glfw.Window) {
-	Mouse = &mouse{
-		window:               window,
+func newMouse(host Host, capture func() bool) *mouse {
+	m := &mouse{
+		host:                 host,
+		capture:              capture,
 		ScrollSpeed:          10,
 		doubleClickThreshold: 0.5,
 		buttonsTracker:       make(map[int]mouseButtonData),
 	}
 
-	Mouse.prevScrollCallback = window.SetScrollCallback(Mouse.scollCallback)
+	host.SetScrollCallback(m.scollCallback)
+
+	return m
 }
 
-func (m *mouse) scollCallback(w *glfw.Window, xoff float64, yoff float64) {
-	m.ScrollDelta += float32(yoff) * m.ScrollSpeed
+func (m *mouse) scollCallback(xoff float64, yoff float64) bool {
+	m.scrollPending += float32(yoff) * m.ScrollSpeed
+	m.scrollPendingX += float32(xoff) * m.ScrollSpeed
 
-	if m.prevScrollCallback != nil {
-		m.prevScrollCallback(w, xoff, yoff)
-	}
+	return m.capture()
 }
 
 //Update should be call each frame
@@ -64,10 +70,13 @@ func (m *mouse) Update() {
 	m.dt = float32(t.Sub(m.frameTime).Seconds())
 	m.frameTime = t
 
-	_, wy := m.window.GetSize()
-	mx, my := m.window.GetCursorPos()
+	_, wy := m.host.GetSize()
+	mx, my := m.host.GetCursorPos()
 	m.X = float32(mx)
 	m.Y = float32(wy) - float32(my)
+
+	m.ScrollDelta, m.scrollPending = m.scrollPending, 0
+	m.ScrollDeltaX, m.scrollPendingX = m.scrollPendingX, 0
 }
 
 //GetPosition of mouse
@@ -91,11 +100,36 @@ type mouseButtonData struct {
 	// sequence was fast enough to be a double click.
 	doubleClickDetected bool
 
+	// clickCount is the number of the presses following each other faster
+	// than the double click threshold, it's 2 for the double click.
+	clickCount int
+
 	// lastCheckedAt should be set to the time the functions last checked
 	// for action. This way, input can be polled only once per frame.
 	lastCheckedAt time.Time
 }
 
+//JustPressed reports whether the button went down in the current frame
+func (m *mouse) JustPressed(button int) bool {
+	if m.GetButtonAction(button) != MouseDown {
+		return false
+	}
+	return m.buttonsTracker[button].lastPress == m.frameTime
+}
+
+//GetButtonDownPosition returns position of the mouse at the last press of the button
+func (m *mouse) GetButtonDownPosition(button int) (x, y float32) {
+	p := m.buttonsTracker[button].lastPressLocation
+	return p[0], p[1]
+}
+
+//GetClickCount returns number of the quick successive presses of the button ended by the last one,
+//it is 1 for the single press, 2 for the double click, 3 for the triple click and so on
+func (m *mouse) GetClickCount(button int) int {
+	m.GetButtonAction(button)
+	return m.buttonsTracker[button].clickCount
+}
+
 //GetButtonAction
 func (m *mouse) GetButtonAction(button int) int {
 	var action int
@@ -110,13 +144,10 @@ func (m *mouse) GetButtonAction(button int) int {
 	}
 
 	// poll the button action
-	glfwAction := window.GetMouseButton(glfw.MouseButton(int(glfw.MouseButton1) + button))
-	if glfwAction == glfw.Release {
-		action = MouseUp
-	} else if glfwAction == glfw.Press {
-		action = MouseDown
-	} else if glfwAction == glfw.Repeat {
+	if m.host.GetMouseButton(button) {
 		action = MouseDown
+	} else {
+		action = MouseUp
 	}
 
 	// see if we're tracking this button yet
@@ -126,19 +157,23 @@ func (m *mouse) GetButtonAction(button int) int {
 			// mx, my := uiman.GetMousePosition()
 			mbData.lastPressLocation = mgl32.Vec2{m.X, m.Y}
 			mbData.lastPress = m.frameTime
+			mbData.clickCount = 1
 		} else {
 			mbData.lastPress = time.Unix(0, 0)
 		}
 	} else {
 		if action == MouseDown {
 			// check to see if there was a transition from UP to DOWN
-			if mbData.lastAction == MouseUp {
+			if mbData.lastAction != MouseDown {
 				// check to see the time between the last UP->DOWN transition
 				// and this one. If it's less than the double click threshold
 				// then change the doubleClickDetected member so that the
 				// next DOWN->UP will return a double click instead.
 				if m.frameTime.Sub(mbData.lastPress).Seconds() < m.doubleClickThreshold {
 					mbData.doubleClickDetected = true
+					mbData.clickCount++
+				} else {
+					mbData.clickCount = 1
 				}
 
 				// count this as a press and log the time
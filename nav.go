@@ -0,0 +1,225 @@
+package fizzgui
+
+import "math"
+
+//NavAction is the directional navigation event of the gamepad or the code
+type NavAction int
+
+const (
+	NavUp NavAction = iota
+	NavDown
+	NavLeft
+	NavRight
+	NavActivate //presses the focused widget as Enter
+	NavCancel   //Escape for the focused widget
+)
+
+//navKeys are the keys the navigation actions are delivered to the widgets as
+var navKeys = [...]Key{
+	NavUp:       KeyUp,
+	NavDown:     KeyDown,
+	NavLeft:     KeyLeft,
+	NavRight:    KeyRight,
+	NavActivate: KeyEnter,
+	NavCancel:   KeyEscape,
+}
+
+//delay of the first repeat and the period of the next ones while the gamepad direction is held
+const (
+	navRepeatDelay = 0.4
+	navRepeatRate  = 0.1
+)
+
+//GamepadMapping binds the buttons and the axes of the controller to the navigation,
+//indices missing in the controller are ignored
+type GamepadMapping struct {
+	Activate, Cancel      int
+	Up, Down, Left, Right int
+
+	//AxesX and AxesY are sticks and hats, positive Y is down as in GLFW
+	AxesX, AxesY []int
+	Deadzone     float32
+}
+
+//DefaultGamepadMapping is the XInput layout: A activates, B cancels, the d-pad and the left stick move the focus
+var DefaultGamepadMapping = GamepadMapping{
+	Activate: 0,
+	Cancel:   1,
+	Up:       10,
+	Right:    11,
+	Down:     12,
+	Left:     13,
+	AxesX:    []int{0, 6},
+	AxesY:    []int{1, 7},
+	Deadzone: 0.5,
+}
+
+//gamepad turns the controller state into the navigation actions
+type gamepad struct {
+	pressed [NavCancel + 1]bool
+	repeat  [NavCancel + 1]float32 //time left to the repeat of the held direction
+}
+
+//Navigate sends the navigation action to the gui, it is handled in the next frame like the gamepad one
+func (g *GUI) Navigate(action NavAction) {
+	g.pendingNav = append(g.pendingNav, action)
+}
+
+//updateNav delivers the navigation actions of the gamepad and the code as key presses,
+//the first direction focuses the first widget if there is no focused one
+func (g *GUI) updateNav() {
+	actions := append(g.pendingNav, g.pollGamepad()...)
+	g.pendingNav = g.pendingNav[:0]
+
+	for _, action := range actions {
+		if action < NavUp || action > NavCancel {
+			continue
+		}
+
+		if g.ActiveWidget == nil && action <= NavRight {
+			if list := g.focusable(); len(list) > 0 {
+				g.Focus(list[0])
+			}
+			continue
+		}
+
+		g.Keys.inject(navKeys[action])
+	}
+}
+
+//pollGamepad returns the actions of the buttons and the directions pressed since the last frame
+//and repeated while they are held
+func (g *GUI) pollGamepad() (actions []NavAction) {
+	host, ok := g.host.(GamepadHost)
+	if !ok {
+		return
+	}
+
+	axes, buttons, ok := host.GetGamepad()
+	if !ok {
+		g.gamepad = gamepad{}
+		return
+	}
+
+	m := g.GamepadMapping
+	button := func(i int) bool {
+		return i >= 0 && i < len(buttons) && buttons[i]
+	}
+	axis := func(indices []int) (v float32) {
+		for _, i := range indices {
+			if i >= 0 && i < len(axes) && math.Abs(float64(axes[i])) > math.Abs(float64(v)) {
+				v = axes[i]
+			}
+		}
+		return
+	}
+
+	x, y := axis(m.AxesX), axis(m.AxesY)
+
+	var state [NavCancel + 1]bool
+	state[NavUp] = button(m.Up) || y < -m.Deadzone
+	state[NavDown] = button(m.Down) || y > m.Deadzone
+	state[NavLeft] = button(m.Left) || x < -m.Deadzone
+	state[NavRight] = button(m.Right) || x > m.Deadzone
+	state[NavActivate] = button(m.Activate)
+	state[NavCancel] = button(m.Cancel)
+
+	pad := &g.gamepad
+	for action, down := range state {
+		a := NavAction(action)
+		switch {
+		case down && !pad.pressed[a]:
+			actions = append(actions, a)
+			pad.repeat[a] = navRepeatDelay
+		case down && a <= NavRight:
+			//directions are repeated while they are held
+			pad.repeat[a] -= g.dt
+			if pad.repeat[a] <= 0 {
+				actions = append(actions, a)
+				pad.repeat[a] += navRepeatRate
+			}
+		}
+		pad.pressed[a] = down
+	}
+
+	return
+}
+
+//moveFocus moves the focus by the arrows which are not handled by the focused widget
+func (g *GUI) moveFocus() {
+	if g.ActiveWidget == nil {
+		return
+	}
+
+	for _, k := range g.Keys.GetKeys() {
+		if g.Keys.used[k.KeyCode] || k.Mods != 0 {
+			continue
+		}
+
+		switch k.KeyCode {
+		case KeyUp:
+			g.focusDirection(0, 1)
+		case KeyDown:
+			g.focusDirection(0, -1)
+		case KeyLeft:
+			g.focusDirection(-1, 0)
+		case KeyRight:
+			g.focusDirection(1, 0)
+		}
+	}
+}
+
+//focusDirection focuses the nearest widget in the direction from the focused one,
+//the distance across the direction counts twice, so the widgets in line are preferred
+func (g *GUI) focusDirection(dx, dy float32) {
+	cur := g.ActiveWidget
+	if cur == nil {
+		return
+	}
+	from := cur.Layout.GetBackgroundRect()
+
+	var best *Widget
+	var bestScore float32
+	for _, wgt := range g.focusable() {
+		if wgt == cur {
+			continue
+		}
+		r := wgt.Layout.GetBackgroundRect()
+
+		//center of the candidate must be ahead of the center of the focused widget
+		cx := (r.TLX + r.BRX - from.TLX - from.BRX) / 2
+		cy := (r.TLY + r.BRY - from.TLY - from.BRY) / 2
+		if cx*dx+cy*dy <= 0 {
+			continue
+		}
+
+		var along, across float32
+		if dx != 0 {
+			along = gap(from.TLX, from.BRX, r.TLX, r.BRX)
+			across = gap(from.BRY, from.TLY, r.BRY, r.TLY)
+		} else {
+			along = gap(from.BRY, from.TLY, r.BRY, r.TLY)
+			across = gap(from.TLX, from.BRX, r.TLX, r.BRX)
+		}
+
+		score := along + 2*across + float32(math.Hypot(float64(cx), float64(cy)))/100
+		if best == nil || score < bestScore {
+			best, bestScore = wgt, score
+		}
+	}
+
+	if best != nil {
+		g.Focus(best)
+	}
+}
+
+//gap returns the distance between the ranges [a0, a1] and [b0, b1], 0 if they overlap
+func gap(a0, a1, b0, b1 float32) float32 {
+	switch {
+	case b0 > a1:
+		return b0 - a1
+	case a0 > b1:
+		return a0 - b1
+	}
+	return 0
+}
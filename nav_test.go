@@ -0,0 +1,61 @@
+package fizzgui
+
+import "testing"
+
+func TestNavigate(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "280px")
+
+	clicks := 0
+	one := c.NewButton("one", func(*Widget) { clicks++ })
+	two := c.NewButton("two", nil)
+	c.NewRow()
+	text := ""
+	in := c.NewInput("in", &text, nil)
+	three := c.NewButton("three", nil)
+	g.Construct()
+	g.Construct()
+
+	//the arrows do not focus, the first direction focuses the first widget
+	host.TypeKey(KeyDown, 0)
+	g.Construct()
+	if g.ActiveWidget != nil {
+		t.Fatal("arrow focuses", g.ActiveWidget)
+	}
+	g.Navigate(NavDown)
+	g.Construct()
+	if g.ActiveWidget != one {
+		t.Fatal("first direction focuses", g.ActiveWidget)
+	}
+
+	for i, step := range []struct {
+		action NavAction
+		want   *Widget
+	}{
+		{NavRight, two},
+		{NavDown, in},
+		{NavDown, three},
+		{NavUp, in},
+		{NavUp, two},
+		{NavLeft, one},
+	} {
+		g.Navigate(step.action)
+		g.Construct()
+		if g.ActiveWidget != step.want {
+			t.Fatalf("step %d focuses %v", i, g.ActiveWidget)
+		}
+	}
+
+	g.Navigate(NavActivate)
+	g.Construct()
+	if clicks != 1 {
+		t.Fatal("activation does not press the button", clicks)
+	}
+
+	//the arrows move the focus as the navigation
+	host.TypeKey(KeyRight, 0)
+	g.Construct()
+	if g.ActiveWidget != two {
+		t.Fatal("arrow focuses", g.ActiveWidget)
+	}
+}
@@ -0,0 +1,173 @@
+package fizzgui
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+//radioSize is the diameter of the radio button circle
+const radioSize = 16
+
+//RadioGroup is the list of the labelled radio buttons, exactly one of them is selected,
+//arrow keys move the selection while an item of the group is active
+type RadioGroup struct {
+	Selected *int //index of the selected item
+
+	OnChange Callback //called with the selected item when the user changes the selection
+
+	items []*radioItem
+
+	keysFrame uint64 //frame the arrows are handled in, the item selected by them is constructed later in it
+}
+
+//radioItem is a radio button with the label in the group
+type radioItem struct {
+	*Widget
+
+	group *RadioGroup
+	index int
+}
+
+//NewRadioGroup creates the vertical list of the radio buttons with the labels,
+//selected is index of the chosen item, callback is called when the user changes it
+func (c *Container) NewRadioGroup(selected *int, labels []string, f Callback) *RadioGroup {
+	group := &RadioGroup{Selected: selected, OnChange: f}
+
+	for i, label := range labels {
+		wgt := &Widget{
+			Text:      label,
+			Font:      c.gui.GetFont(c.FontName),
+			Style:     DefaultTextStyle,
+			Container: c,
+			Layout:    NewLayout("", "", "100%", "", c.Layout),
+		}
+		//the circle is in the left padding between the equal gaps
+		wgt.Layout.Padding.L += radioSize + wgt.Layout.Padding.L
+
+		item := &radioItem{wgt, group, i}
+		item.updateState()
+		wgt.ConstructorData = item
+		wgt.Constructor = item.constructor
+
+		c.addWidget(wgt)
+		group.items = append(group.items, item)
+	}
+
+	return group
+}
+
+//SetVertical places the items one under another or in a row
+func (group *RadioGroup) SetVertical(vertical bool) {
+	for _, item := range group.items {
+		if vertical {
+			item.Layout.SetWidth("100%")
+		} else {
+			item.Layout.SetWidth("")
+		}
+	}
+}
+
+//Item returns widget of the radio button by the index
+func (group *RadioGroup) Item(i int) *Widget {
+	return group.items[i].Widget
+}
+
+//Len returns the number of the radio buttons
+func (group *RadioGroup) Len() int {
+	return len(group.items)
+}
+
+//Select changes the selected item, the callback is called if the selection is changed
+func (group *RadioGroup) Select(i int) {
+	if i < 0 || i >= len(group.items) || i == *group.Selected {
+		return
+	}
+
+	*group.Selected = i
+	for _, item := range group.items {
+		item.updateState()
+	}
+
+	if group.OnChange != nil {
+		group.OnChange(group.items[i].Widget)
+	}
+}
+
+func (item *radioItem) constructor() (style Style) {
+	gui := item.Container.gui
+	group := item.group
+
+	if click, onWidget := item.IsClick(); click {
+		if onWidget {
+			group.Select(item.index)
+			gui.ActiveWidget = item.Widget
+		} else if gui.ActiveWidget == item.Widget {
+			gui.ActiveWidget = nil
+		}
+	}
+
+	if gui.ActiveWidget == item.Widget && group.keysFrame != gui.frame {
+		group.keysFrame = gui.frame
+
+		//arrows past the first and the last items are left to the focus navigation
+		next := *group.Selected
+		for _, k := range gui.Keys.GetKeys() {
+			switch k.KeyCode {
+			case KeyUp, KeyLeft:
+				if next > 0 {
+					next--
+					gui.Keys.use(k.KeyCode)
+				}
+			case KeyDown, KeyRight:
+				if next < len(group.items)-1 {
+					next++
+					gui.Keys.use(k.KeyCode)
+				}
+			case KeyEscape:
+				gui.ActiveWidget = nil
+			}
+		}
+
+		if next != *group.Selected && next >= 0 && next < len(group.items) {
+			group.Select(next)
+			gui.ActiveWidget = group.items[next].Widget
+		}
+	}
+
+	item.updateState()
+	item.drawButton()
+
+	return
+}
+
+//updateState marks the selected item checked, only it takes part in the tab order
+func (item *radioItem) updateState() {
+	if item.index == *item.group.Selected {
+		item.State = STATE_CHECKED
+	} else {
+		item.State = STATE_NORMAL
+	}
+	sel := *item.group.Selected
+	item.Focusable = item.index == sel || item.index == 0 && (sel < 0 || sel >= len(item.group.items))
+}
+
+//drawButton draws the circle at the left padding, the selected one has the dot inside
+func (item *radioItem) drawButton() {
+	gui := item.Container.gui
+	r := item.Layout.GetBackgroundRect()
+	center := mgl32.Vec2{r.TLX + item.Layout.Padding.L/2, r.TLY - r.H/2}
+
+	style := DefaultInputStyle
+	if item.IsHover() {
+		style = DefaultInputStyleActive
+	}
+
+	cmd := gui.GetLastCmd(item.Z + 1)
+	if gui.ActiveWidget == item.Widget {
+		cmd.DrawFilledCircle(center, radioSize/2+1, BorderColorHiglight)
+	}
+	cmd.DrawFilledCircle(center, radioSize/2, style.BackgroundColor)
+
+	if item.State == STATE_CHECKED {
+		cmd.DrawFilledCircle(center, radioSize/4, style.TextColor)
+	}
+}
@@ -0,0 +1,31 @@
+package fizzgui
+
+import (
+	"image"
+
+	graphics "github.com/tbogdala/fizzle/graphicsprovider"
+)
+
+//Renderer draws the geometry built by Construct and owns the textures it samples.
+//NewGLRenderer draws with OpenGL, SoftwareRenderer rasterizes to an image on the CPU.
+type Renderer interface {
+	//NewTexture creates texture from the image, the first row of the image is at v=0.
+	//Smooth textures are sampled with linear filtering where it is supported.
+	NewTexture(img *image.RGBA, smooth bool) graphics.Texture
+	DeleteTexture(tex graphics.Texture)
+
+	//Render draws batches in the given order to the window of the w*h size
+	Render(w, h float32, batches []Batch)
+}
+
+//Batch contains the data of one draw call
+type Batch struct {
+	ComboBuffer []float32 // per vertex: x, y, u, v, texture index, r, g, b, a
+	IndexBuffer []uint32  // three indexes per face
+	Faces       uint32
+	Texture     graphics.Texture
+	Clip        Rect // nothing is drawn outside of this rect
+}
+
+//vboStride is the count of floats describing one vertex in the combo buffer
+const vboStride = 9
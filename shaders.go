@@ -51,76 +51,76 @@ void main() {
 	//frag_color = color * texture(TEX, uv);
 }`
 
-func compileShader(vertShader, fragShader string) (graphics.Program, error) {
+func (r *glRenderer) compileShader(vertShader, fragShader string) (graphics.Program, error) {
 	// create the program
-	prog := gfx.CreateProgram()
+	prog := r.gfx.CreateProgram()
 
 	// create the vertex shader
 	var status int32
-	vs := gfx.CreateShader(graphics.VERTEX_SHADER)
-	gfx.ShaderSource(vs, vertShader)
-	gfx.CompileShader(vs)
-	gfx.GetShaderiv(vs, graphics.COMPILE_STATUS, &status)
+	vs := r.gfx.CreateShader(graphics.VERTEX_SHADER)
+	r.gfx.ShaderSource(vs, vertShader)
+	r.gfx.CompileShader(vs)
+	r.gfx.GetShaderiv(vs, graphics.COMPILE_STATUS, &status)
 	if status == graphics.FALSE {
-		log := gfx.GetShaderInfoLog(vs)
+		log := r.gfx.GetShaderInfoLog(vs)
 		return 0, fmt.Errorf("Failed to compile the vertex shader:\n%s", log)
 	}
-	defer gfx.DeleteShader(vs)
+	defer r.gfx.DeleteShader(vs)
 
 	// create the fragment shader
-	fs := gfx.CreateShader(graphics.FRAGMENT_SHADER)
-	gfx.ShaderSource(fs, fragShader)
-	gfx.CompileShader(fs)
-	gfx.GetShaderiv(fs, graphics.COMPILE_STATUS, &status)
+	fs := r.gfx.CreateShader(graphics.FRAGMENT_SHADER)
+	r.gfx.ShaderSource(fs, fragShader)
+	r.gfx.CompileShader(fs)
+	r.gfx.GetShaderiv(fs, graphics.COMPILE_STATUS, &status)
 	if status == graphics.FALSE {
-		log := gfx.GetShaderInfoLog(fs)
+		log := r.gfx.GetShaderInfoLog(fs)
 		return 0, fmt.Errorf("Failed to compile the fragment shader:\n%s", log)
 	}
-	defer gfx.DeleteShader(fs)
+	defer r.gfx.DeleteShader(fs)
 
 	// attach the shaders to the program and link
-	gfx.AttachShader(prog, vs)
-	gfx.AttachShader(prog, fs)
-	gfx.LinkProgram(prog)
-	gfx.GetProgramiv(prog, graphics.LINK_STATUS, &status)
+	r.gfx.AttachShader(prog, vs)
+	r.gfx.AttachShader(prog, fs)
+	r.gfx.LinkProgram(prog)
+	r.gfx.GetProgramiv(prog, graphics.LINK_STATUS, &status)
 	if status == graphics.FALSE {
-		log := gfx.GetProgramInfoLog(prog)
+		log := r.gfx.GetProgramInfoLog(prog)
 		return 0, fmt.Errorf("Failed to link the program!\n%s", log)
 	}
 
 	return prog, nil
 }
 
-func bindShader(view mgl.Mat4) {
+func (r *glRenderer) bindShader(view mgl.Mat4) {
 	const posOffset = 0
 	const uvOffset = 8
 	const colorOffset = 20
 	const VBOStride = 36
 
-	gfx.UseProgram(mainShader)
-	gfx.BindVertexArray(vao)
+	r.gfx.UseProgram(r.mainShader)
+	r.gfx.BindVertexArray(r.vao)
 
-	TEX := gfx.GetUniformLocation(mainShader, "TEX")
-	gfx.ActiveTexture(graphics.TEXTURE0)
-	// gfx.BindTexture(graphics.TEXTURE_2D, tex)
-	gfx.Uniform1i(TEX, 0)
+	TEX := r.gfx.GetUniformLocation(r.mainShader, "TEX")
+	r.gfx.ActiveTexture(graphics.TEXTURE0)
+	// r.gfx.BindTexture(graphics.TEXTURE_2D, tex)
+	r.gfx.Uniform1i(TEX, 0)
 
 	// bind the uniforms and attributes
-	VIEW := gfx.GetUniformLocation(mainShader, "VIEW")
-	gfx.UniformMatrix4fv(VIEW, 1, false, view)
+	VIEW := r.gfx.GetUniformLocation(r.mainShader, "VIEW")
+	r.gfx.UniformMatrix4fv(VIEW, 1, false, view)
 
-	VERTEX_POSITION := gfx.GetAttribLocation(mainShader, "VERTEX_POSITION")
-	gfx.BindBuffer(graphics.ARRAY_BUFFER, comboVBO)
-	gfx.EnableVertexAttribArray(uint32(VERTEX_POSITION))
-	gfx.VertexAttribPointer(uint32(VERTEX_POSITION), 2, graphics.FLOAT, false, VBOStride, gfx.PtrOffset(posOffset))
+	VERTEX_POSITION := r.gfx.GetAttribLocation(r.mainShader, "VERTEX_POSITION")
+	r.gfx.BindBuffer(graphics.ARRAY_BUFFER, r.comboVBO)
+	r.gfx.EnableVertexAttribArray(uint32(VERTEX_POSITION))
+	r.gfx.VertexAttribPointer(uint32(VERTEX_POSITION), 2, graphics.FLOAT, false, VBOStride, r.gfx.PtrOffset(posOffset))
 
-	VERTEX_UV := gfx.GetAttribLocation(mainShader, "VERTEX_UV")
-	gfx.EnableVertexAttribArray(uint32(VERTEX_UV))
-	gfx.VertexAttribPointer(uint32(VERTEX_UV), 2, graphics.FLOAT, false, VBOStride, gfx.PtrOffset(uvOffset))
+	VERTEX_UV := r.gfx.GetAttribLocation(r.mainShader, "VERTEX_UV")
+	r.gfx.EnableVertexAttribArray(uint32(VERTEX_UV))
+	r.gfx.VertexAttribPointer(uint32(VERTEX_UV), 2, graphics.FLOAT, false, VBOStride, r.gfx.PtrOffset(uvOffset))
 
-	VERTEX_COLOR := gfx.GetAttribLocation(mainShader, "VERTEX_COLOR")
-	gfx.EnableVertexAttribArray(uint32(VERTEX_COLOR))
-	gfx.VertexAttribPointer(uint32(VERTEX_COLOR), 4, graphics.FLOAT, false, VBOStride, gfx.PtrOffset(colorOffset))
+	VERTEX_COLOR := r.gfx.GetAttribLocation(r.mainShader, "VERTEX_COLOR")
+	r.gfx.EnableVertexAttribArray(uint32(VERTEX_COLOR))
+	r.gfx.VertexAttribPointer(uint32(VERTEX_COLOR), 4, graphics.FLOAT, false, VBOStride, r.gfx.PtrOffset(colorOffset))
 
-	gfx.BindBuffer(graphics.ELEMENT_ARRAY_BUFFER, indexVBO)
+	r.gfx.BindBuffer(graphics.ELEMENT_ARRAY_BUFFER, r.indexVBO)
 }
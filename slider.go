@@ -0,0 +1,221 @@
+package fizzgui
+
+import (
+	"fmt"
+	"math"
+)
+
+//sliderThumbSize is the length of the thumb along the slider
+const sliderThumbSize = 12
+
+//Slider changes the value by the draggable thumb, the click on the track moves the thumb to it,
+//arrows along the slider, page up/down, home and end keys change the value while the slider is active
+type Slider struct {
+	*Widget
+
+	Value    *float32
+	Min, Max float32
+
+	Step      float32 //value is snapped to the steps if it is more than 0
+	Vertical  bool    //min is at the bottom, the layout should be made tall
+	ShowValue bool    //value is drawn in the middle of the slider
+	Format    string  //fmt verb of the shown value, "%.2f" by default
+
+	StyleFill       Style
+	StyleThumb      Style
+	StyleThumbHover Style
+
+	dragging   bool
+	grabOffset float32 //distance from the thumb center to the mouse press
+}
+
+//NewSlider creates horizontal slider of the value between min and max, callback is called when the value is changed
+func (c *Container) NewSlider(value *float32, min, max float32, f Callback) *Slider {
+	wgt := &Widget{
+		Font:        c.gui.GetFont(c.FontName),
+		Style:       DefaultInputStyle,
+		StyleActive: DefaultInputStyleActive,
+		Container:   c,
+		Layout:      NewLayout("", "", "100%", "28px", c.Layout),
+		OnActive:    f,
+		Focusable:   true,
+	}
+
+	s := &Slider{
+		Widget:          wgt,
+		Value:           value,
+		Min:             min,
+		Max:             max,
+		Format:          "%.2f",
+		StyleFill:       DefaultSliderFillStyle,
+		StyleThumb:      DefaultSliderThumbStyle,
+		StyleThumbHover: DefaultSliderThumbStyleHover,
+	}
+
+	wgt.ConstructorData = s
+	wgt.Constructor = s.constructor
+
+	c.addWidget(wgt)
+	return s
+}
+
+func (s *Slider) constructor() (style Style) {
+	gui := s.Container.gui
+	mouse := gui.Mouse
+	r := s.Layout.GetContentRect()
+
+	thumb := s.thumbRect(r)
+
+	if mouse.JustPressed(0) && s.IsHover() {
+		gui.ActiveWidget = s.Widget
+		s.dragging = true
+
+		//the press on the track moves the thumb under the mouse
+		s.grabOffset = 0
+		if thumb.ContainsPoint(mouse.X, mouse.Y) {
+			s.grabOffset = s.axis(mouse.X, mouse.Y) - s.axis(thumb.TLX+thumb.W/2, thumb.TLY-thumb.H/2)
+		}
+	}
+
+	if s.dragging {
+		if mouse.GetButtonAction(0) == MouseDown {
+			s.setValue(s.valueAt(r, s.axis(mouse.X, mouse.Y)-s.grabOffset))
+		} else {
+			s.dragging = false
+		}
+	}
+
+	if click, onWidget := s.IsClick(); click && !onWidget && gui.ActiveWidget == s.Widget {
+		gui.ActiveWidget = nil
+	}
+
+	if gui.ActiveWidget == s.Widget {
+		step := s.Step
+		if step <= 0 {
+			step = (s.Max - s.Min) / 100
+		}
+
+		for _, k := range gui.Keys.GetKeys() {
+			switch k.KeyCode {
+			case KeyRight, KeyUp, KeyLeft, KeyDown:
+				//arrows across the slider are left to the focus navigation
+				vertical := k.KeyCode == KeyUp || k.KeyCode == KeyDown
+				if vertical != s.Vertical {
+					break
+				}
+
+				gui.Keys.use(k.KeyCode)
+				if k.KeyCode == KeyRight || k.KeyCode == KeyUp {
+					s.setValue(*s.Value + step)
+				} else {
+					s.setValue(*s.Value - step)
+				}
+			case KeyPageUp:
+				s.setValue(*s.Value + step*10)
+			case KeyPageDown:
+				s.setValue(*s.Value - step*10)
+			case KeyHome:
+				s.setValue(s.Min)
+			case KeyEnd:
+				s.setValue(s.Max)
+			case KeyEscape:
+				gui.ActiveWidget = nil
+			}
+		}
+
+		style = s.StyleActive
+	}
+
+	s.render(r)
+
+	return
+}
+
+//setValue changes the value snapped to the step and limited by min and max
+func (s *Slider) setValue(v float32) {
+	if s.Step > 0 {
+		v = s.Min + float32(math.Round(float64((v-s.Min)/s.Step)))*s.Step
+	}
+	v = clampFloat(v, s.Min, s.Max)
+
+	if v != *s.Value {
+		*s.Value = v
+		if s.OnActive != nil {
+			s.OnActive(s.Widget)
+		}
+	}
+}
+
+//axis returns the coordinate along the slider
+func (s *Slider) axis(x, y float32) float32 {
+	if s.Vertical {
+		return y
+	}
+	return x
+}
+
+//valueAt returns the value with the thumb center at the position along the slider
+func (s *Slider) valueAt(r Rect, pos float32) float32 {
+	start, length := r.TLX, r.W
+	if s.Vertical {
+		start, length = r.BRY, r.H
+	}
+
+	length -= sliderThumbSize
+	if length <= 0 || s.Max <= s.Min {
+		return s.Min
+	}
+
+	return s.Min + (pos-start-sliderThumbSize/2)/length*(s.Max-s.Min)
+}
+
+//ratio returns position of the value between min and max from 0 to 1
+func (s *Slider) ratio() float32 {
+	if s.Max <= s.Min {
+		return 0
+	}
+	return clampFloat((*s.Value-s.Min)/(s.Max-s.Min), 0, 1)
+}
+
+func (s *Slider) thumbRect(r Rect) Rect {
+	t := r
+	if s.Vertical {
+		t.BRY = r.BRY + (r.H-sliderThumbSize)*s.ratio()
+		t.TLY = t.BRY + sliderThumbSize
+		t.H = sliderThumbSize
+	} else {
+		t.TLX = r.TLX + (r.W-sliderThumbSize)*s.ratio()
+		t.BRX = t.TLX + sliderThumbSize
+		t.W = sliderThumbSize
+	}
+	return t
+}
+
+func (s *Slider) render(r Rect) {
+	gui := s.Container.gui
+	cmd := gui.GetLastCmd(s.Z + 1)
+
+	thumb := s.thumbRect(r)
+
+	fill := r
+	if s.Vertical {
+		fill.TLY = thumb.TLY - thumb.H/2
+		fill.H = fill.TLY - fill.BRY
+	} else {
+		fill.BRX = thumb.TLX + thumb.W/2
+		fill.W = fill.BRX - fill.TLX
+	}
+	cmd.DrawFilledRect(fill, s.StyleFill.BackgroundColor, defaultTextureSampler, whitePixelUv)
+
+	thumbStyle := s.StyleThumb
+	if s.dragging || s.IsHover() && thumb.ContainsPoint(gui.Mouse.X, gui.Mouse.Y) {
+		thumbStyle = s.StyleThumbHover
+	}
+	cmd.DrawFilledRect(thumb, thumbStyle.BackgroundColor, defaultTextureSampler, whitePixelUv)
+
+	if !s.ShowValue || s.Font == nil {
+		return
+	}
+
+	gui.drawTextCentered(s.Font, r, s.Style.TextColor, fmt.Sprintf(s.Format, *s.Value), s.Z+1)
+}
@@ -0,0 +1,32 @@
+package fizzgui
+
+import "testing"
+
+func TestSliderStep(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+
+	value := float32(0)
+	s := c.NewSlider(&value, 0, 10, nil)
+	s.Step = 2.5
+	g.Construct()
+	g.Construct()
+
+	r := s.Layout.GetContentRect()
+	y := r.TLY - r.H/2
+	at := func(v float32) float32 {
+		return r.TLX + sliderThumbSize/2 + v/10*(r.W-sliderThumbSize)
+	}
+
+	//the click on the track is snapped to the nearest step
+	for _, tt := range []struct{ click, want float32 }{
+		{3.4, 2.5},
+		{3.9, 5},
+		{9.6, 10},
+	} {
+		clickPoint(g, host, at(tt.click), y)
+		if value != tt.want {
+			t.Errorf("click at %v sets %v, want %v", tt.click, value, tt.want)
+		}
+	}
+}
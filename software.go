@@ -0,0 +1,207 @@
+package fizzgui
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	graphics "github.com/tbogdala/fizzle/graphicsprovider"
+)
+
+//SoftwareRenderer rasterizes the gui on the CPU into an image. It needs no
+//graphics context, so a constructed frame may be compared with golden images
+//in plain go test. Textures are sampled with the nearest filter.
+type SoftwareRenderer struct {
+	//ClearColor fills the image before each frame
+	ClearColor color.RGBA
+
+	img      *image.RGBA
+	textures map[graphics.Texture]*image.RGBA
+	lastTex  graphics.Texture
+}
+
+//NewSoftwareRenderer creates renderer drawing to an image
+func NewSoftwareRenderer() *SoftwareRenderer {
+	return &SoftwareRenderer{
+		img:      image.NewRGBA(image.Rect(0, 0, 0, 0)),
+		textures: make(map[graphics.Texture]*image.RGBA),
+	}
+}
+
+//Image returns the result of the last Render, the image has the size of the window
+func (r *SoftwareRenderer) Image() *image.RGBA {
+	return r.img
+}
+
+// NewTexture keeps a copy of the image, handles start from 1 like in OpenGL.
+func (r *SoftwareRenderer) NewTexture(img *image.RGBA, smooth bool) graphics.Texture {
+	r.lastTex++
+
+	tex := image.NewRGBA(image.Rect(0, 0, img.Rect.Dx(), img.Rect.Dy()))
+	for y := 0; y < tex.Rect.Dy(); y++ {
+		copy(tex.Pix[y*tex.Stride:(y+1)*tex.Stride], img.Pix[img.PixOffset(img.Rect.Min.X, img.Rect.Min.Y+y):])
+	}
+
+	r.textures[r.lastTex] = tex
+	return r.lastTex
+}
+
+func (r *SoftwareRenderer) DeleteTexture(tex graphics.Texture) {
+	delete(r.textures, tex)
+}
+
+// Render clears the image and rasterizes every triangle of the batches in order.
+func (r *SoftwareRenderer) Render(w, h float32, batches []Batch) {
+	size := image.Rect(0, 0, int(w), int(h))
+	if r.img.Rect != size {
+		r.img = image.NewRGBA(size)
+	}
+
+	for i := 0; i < len(r.img.Pix); i += 4 {
+		r.img.Pix[i+0] = r.ClearColor.R
+		r.img.Pix[i+1] = r.ClearColor.G
+		r.img.Pix[i+2] = r.ClearColor.B
+		r.img.Pix[i+3] = r.ClearColor.A
+	}
+
+	for _, b := range batches {
+		tex := r.textures[b.Texture]
+
+		for f := 0; f < int(b.Faces) && f*3+2 < len(b.IndexBuffer); f++ {
+			var tri [3][]float32
+			for i := range tri {
+				idx := int(b.IndexBuffer[f*3+i]) * vboStride
+				if idx+vboStride > len(b.ComboBuffer) {
+					continue
+				}
+				tri[i] = b.ComboBuffer[idx : idx+vboStride]
+			}
+			if tri[0] == nil || tri[1] == nil || tri[2] == nil {
+				continue
+			}
+
+			r.drawTriangle(tri, tex, b.Clip)
+		}
+	}
+}
+
+// drawTriangle fills the pixels whose centers are covered by the triangle and the clip rect.
+// The gui coordinates are pixel based with the origin in the bottom left corner,
+// the projection is the same as the one of the OpenGL renderer.
+func (r *SoftwareRenderer) drawTriangle(v [3][]float32, tex *image.RGBA, clip Rect) {
+	x0, y0 := v[0][0], v[0][1]
+	x1, y1 := v[1][0], v[1][1]
+	x2, y2 := v[2][0], v[2][1]
+
+	area := (x1-x0)*(y2-y0) - (x2-x0)*(y1-y0)
+	if area == 0 {
+		return
+	}
+
+	w := r.img.Rect.Dx()
+	h := r.img.Rect.Dy()
+
+	// the center of the pixel column i is at x=i+1
+	minX := clampInt(int(math.Floor(float64(min3(x0, x1, x2))))-1, 0, w-1)
+	maxX := clampInt(int(math.Ceil(float64(max3(x0, x1, x2)))), 0, w-1)
+	minY := clampInt(int(math.Floor(float64(min3(y0, y1, y2))))-1, 0, h-1)
+	maxY := clampInt(int(math.Ceil(float64(max3(y0, y1, y2)))), 0, h-1)
+
+	// the sample point is nudged off the pixel center, so the pixels on the
+	// shared edges are filled once with the left and top edges winning like in OpenGL
+	const nudgeX = 1.0 / 256
+	const nudgeY = 1.0 / 512
+
+	for j := minY; j <= maxY; j++ {
+		py := float32(j) + 1 - nudgeY
+		for i := minX; i <= maxX; i++ {
+			px := float32(i) + 1 + nudgeX
+			if px < clip.TLX || px >= clip.BRX || py <= clip.BRY || py > clip.TLY {
+				continue
+			}
+
+			// barycentric weights of the pixel center
+			b0 := ((x1-px)*(y2-py) - (x2-px)*(y1-py)) / area
+			b1 := ((x2-px)*(y0-py) - (x0-px)*(y2-py)) / area
+			b2 := 1 - b0 - b1
+			if b0 < 0 || b1 < 0 || b2 < 0 {
+				continue
+			}
+
+			var c [4]float32
+			for k := range c {
+				c[k] = v[0][5+k]*b0 + v[1][5+k]*b1 + v[2][5+k]*b2
+			}
+
+			if tex != nil {
+				u := v[0][2]*b0 + v[1][2]*b1 + v[2][2]*b2
+				t := v[0][3]*b0 + v[1][3]*b1 + v[2][3]*b2
+				texel := sampleNearest(tex, u, t)
+				for k := range c {
+					c[k] *= float32(texel[k]) / 255
+				}
+			}
+
+			r.blend(i, h-1-j, c)
+		}
+	}
+}
+
+// blend mixes the color into the pixel as SRC_ALPHA, ONE_MINUS_SRC_ALPHA does.
+func (r *SoftwareRenderer) blend(x, y int, c [4]float32) {
+	a := clampFloat(c[3], 0, 1)
+	if a == 0 {
+		return
+	}
+
+	p := r.img.Pix[r.img.PixOffset(x, y):]
+	for k := 0; k < 3; k++ {
+		dst := float32(p[k]) / 255
+		p[k] = uint8(clampFloat(c[k]*a+dst*(1-a), 0, 1)*255 + 0.5)
+	}
+	dst := float32(p[3]) / 255
+	p[3] = uint8(clampFloat(a+dst*(1-a), 0, 1)*255 + 0.5)
+}
+
+// sampleNearest returns the texel at u,v clamped to the edges of the texture.
+func sampleNearest(tex *image.RGBA, u, v float32) [4]uint8 {
+	w := tex.Rect.Dx()
+	h := tex.Rect.Dy()
+	if w == 0 || h == 0 {
+		return [4]uint8{255, 255, 255, 255}
+	}
+
+	x := clampInt(int(u*float32(w)), 0, w-1)
+	y := clampInt(int(v*float32(h)), 0, h-1)
+
+	p := tex.Pix[tex.PixOffset(x, y):]
+	return [4]uint8{p[0], p[1], p[2], p[3]}
+}
+
+func clampInt(n, min, max int) int {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+func clampFloat(n, min, max float32) float32 {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+func min3(a, b, c float32) float32 {
+	return float32(math.Min(float64(a), math.Min(float64(b), float64(c))))
+}
+
+func max3(a, b, c float32) float32 {
+	return float32(math.Max(float64(a), math.Max(float64(b), float64(c))))
+}
@@ -0,0 +1,96 @@
+package fizzgui
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden images in testdata")
+
+//checkGolden compares the frame with testdata/name.png, the -update flag writes the frame instead
+func checkGolden(t *testing.T, r *SoftwareRenderer, name string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".png")
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, r.Image()); err != nil {
+		t.Fatal(err)
+	}
+
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("%v, run go test -update to create it", err)
+	}
+	defer f.Close()
+
+	golden, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frame := r.Image()
+	if !golden.Bounds().Eq(frame.Bounds()) {
+		t.Fatalf("frame size %v, golden %v", frame.Bounds(), golden.Bounds())
+	}
+
+	diff := 0
+	b := frame.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r0, g0, b0, a0 := golden.At(x, y).RGBA()
+			r1, g1, b1, a1 := frame.At(x, y).RGBA()
+			if r0 != r1 || g0 != g1 || b0 != b1 || a0 != a1 {
+				diff++
+			}
+		}
+	}
+
+	if diff > 0 {
+		actual := filepath.Join(os.TempDir(), name+".actual.png")
+		os.WriteFile(actual, buf.Bytes(), 0644)
+		t.Fatalf("%d pixels differ from %s, the frame is saved to %s", diff, path, actual)
+	}
+}
+
+func TestGoldenWidgets(t *testing.T) {
+	g, _, r := newTestGUI(t, 320, 240)
+
+	c := g.NewContainer("c", "10px", "10px", "300px", "220px")
+	c.NewText("text")
+	c.NewButton("button", nil)
+	ok := true
+	c.NewCheckboxLabel(&ok, "checkbox", nil)
+	c.NewRow()
+
+	text := "input"
+	c.NewInput("in", &text, nil)
+
+	value := float32(0.3)
+	c.NewSlider(&value, 0, 1, nil)
+	c.NewProgressBar(&value, 0, 1, nil)
+
+	//layout is measured in the first frame
+	g.Construct()
+	g.Construct()
+
+	if r.Image().Bounds() != image.Rect(0, 0, 320, 240) {
+		t.Fatal("frame size", r.Image().Bounds())
+	}
+	checkGolden(t, r, "widgets")
+}
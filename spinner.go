@@ -0,0 +1,290 @@
+package fizzgui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+//spinnerButtonSize is the width of the +/- buttons at the right side of the spinner
+const spinnerButtonSize = 20
+
+//spinnerDragPixels is the mouse move changing the value by one step
+const spinnerDragPixels = 4
+
+type spinner struct {
+	wgt *Widget
+
+	get    func() float64
+	set    func(v float64)
+	format func() string
+	plain  func() string //value without the format, it is edited and parsed back
+	parse  func(s string) (float64, error)
+
+	min, max, step float64
+
+	//text edit started by the double click
+	editing bool
+	text    string
+	edit    *input
+
+	scrubbing bool //the value is dragged by the mouse
+	dragValue float64
+}
+
+//NewSpinner creates editor of the int value with the +/- buttons, the value is changed by the step,
+//it is also dragged by the mouse and typed after the double click, format is the fmt verb ex: "%d",
+//the value is not limited if min is equal to max
+func (c *Container) NewSpinner(value *int, min, max, step int, format string, f Callback) *Widget {
+	if format == "" {
+		format = "%d"
+	}
+
+	sp := &spinner{
+		get:    func() float64 { return float64(*value) },
+		set:    func(v float64) { *value = int(math.Round(v)) },
+		format: func() string { return fmt.Sprintf(format, *value) },
+		plain:  func() string { return strconv.Itoa(*value) },
+		parse: func(s string) (float64, error) {
+			v, err := strconv.Atoi(s)
+			return float64(v), err
+		},
+		min:  float64(min),
+		max:  float64(max),
+		step: float64(step),
+	}
+
+	return c.newSpinner(sp, FilterDigits, f)
+}
+
+//NewDragFloat creates editor of the float value dragged by the mouse with the +/- buttons,
+//the value is typed after the double click, format is the fmt verb ex: "%.2f",
+//the value is not limited if min is equal to max
+func (c *Container) NewDragFloat(value *float32, min, max, step float32, format string, f Callback) *Widget {
+	if format == "" {
+		format = "%.3f"
+	}
+
+	sp := &spinner{
+		get:    func() float64 { return float64(*value) },
+		set:    func(v float64) { *value = float32(v) },
+		format: func() string { return fmt.Sprintf(format, *value) },
+		plain:  func() string { return strconv.FormatFloat(float64(*value), 'f', -1, 32) },
+		parse: func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 32)
+		},
+		min:  float64(min),
+		max:  float64(max),
+		step: float64(step),
+	}
+
+	return c.newSpinner(sp, func(ch rune) bool {
+		return FilterDigits(ch) || ch == '.' || ch == 'e' || ch == 'E' || ch == '+'
+	}, f)
+}
+
+func (c *Container) newSpinner(sp *spinner, filter func(rune) bool, f Callback) *Widget {
+	wgt := &Widget{
+		Font:        c.gui.GetFont(c.FontName),
+		TextAlign:   TALIGN_CENTER,
+		Style:       DefaultInputStyle,
+		StyleActive: DefaultInputStyleActive,
+		Container:   c,
+		Layout:      NewLayout("", "", "100%", "28px", c.Layout),
+		OnActive:    f,
+		Focusable:   true,
+	}
+	wgt.Layout.Padding.R += 2 * spinnerButtonSize
+
+	sp.wgt = wgt
+	sp.edit = &input{
+		textEdit: newTextEdit(&sp.text),
+		InputOptions: InputOptions{Filter: func(ch rune) bool {
+			return ch == '-' || filter(ch)
+		}},
+	}
+	wgt.Text = sp.format()
+
+	wgt.ConstructorData = sp
+	wgt.Constructor = wgt.spinnerConstructor
+
+	c.addWidget(wgt)
+	return wgt
+}
+
+func (wgt *Widget) spinnerConstructor() (style Style) {
+	gui := wgt.Container.gui
+	sp := wgt.ConstructorData.(*spinner)
+
+	if sp.editing {
+		cancel := false
+		for _, k := range gui.Keys.GetKeys() {
+			if k.KeyCode == KeyEscape {
+				cancel = true
+			}
+		}
+
+		style = sp.edit.construct(wgt)
+		if gui.ActiveWidget == wgt {
+			return
+		}
+
+		//enter, escape or click outside finished the edit
+		sp.finishEdit(cancel)
+		style = Style{}
+	}
+
+	wgt.Text = sp.format()
+
+	mouse := gui.Mouse
+	minus, plus := sp.buttons()
+
+	action := mouse.GetButtonAction(0)
+	px, py := mouse.GetButtonDownPosition(0)
+	onButtons := minus.ContainsPoint(px, py) || plus.ContainsPoint(px, py)
+
+	if mouse.JustPressed(0) && wgt.IsHover() && !onButtons {
+		sp.scrubbing = true
+		sp.dragValue = sp.get()
+		gui.ActiveWidget = wgt
+	}
+
+	if sp.scrubbing {
+		if action == MouseDown {
+			//small moves are kept for the clicks
+			if dx := mouse.X - px; dx > 2 || dx < -2 {
+				sp.change(sp.dragValue + math.Round(float64(dx/spinnerDragPixels))*sp.step)
+			}
+		} else {
+			sp.scrubbing = false
+			if gui.ActiveWidget == wgt {
+				gui.ActiveWidget = nil
+			}
+		}
+	}
+
+	click, onWidget := wgt.IsClick()
+	if click && onWidget {
+		switch {
+		case minus.ContainsPoint(px, py) && minus.ContainsPoint(mouse.X, mouse.Y):
+			sp.change(sp.get() - sp.step)
+		case plus.ContainsPoint(px, py) && plus.ContainsPoint(mouse.X, mouse.Y):
+			sp.change(sp.get() + sp.step)
+		case action == MouseDoubleClick && !onButtons:
+			sp.startEdit()
+		}
+	}
+
+	//the focused spinner is changed by the left and right arrows, enter starts the text edit
+	if wgt.IsFocused() && !sp.editing && !sp.scrubbing {
+		if mouse.JustPressed(0) && !wgt.IsHover() {
+			gui.ActiveWidget = nil
+		}
+
+		for _, k := range gui.Keys.GetKeys() {
+			switch k.KeyCode {
+			case KeyRight:
+				gui.Keys.use(KeyRight)
+				sp.change(sp.get() + sp.step)
+			case KeyLeft:
+				gui.Keys.use(KeyLeft)
+				sp.change(sp.get() - sp.step)
+			case KeyEnter, KeyKPEnter:
+				sp.startEdit()
+			}
+		}
+	}
+
+	if !sp.editing {
+		sp.drawButtons(minus, plus)
+	}
+
+	if sp.scrubbing {
+		style = wgt.StyleActive
+	}
+
+	return
+}
+
+//change sets the value limited by min and max, the callback is called if the value is changed
+func (sp *spinner) change(v float64) {
+	if sp.min != sp.max {
+		v = math.Max(sp.min, math.Min(sp.max, v))
+	}
+
+	prev := sp.format()
+	sp.set(v)
+	sp.wgt.Text = sp.format()
+
+	if sp.wgt.Text != prev && sp.wgt.OnActive != nil {
+		sp.wgt.OnActive(sp.wgt)
+	}
+}
+
+func (sp *spinner) startEdit() {
+	sp.editing = true
+	sp.text = sp.plain()
+	sp.edit.sync()
+	sp.edit.clearHistory()
+	sp.edit.selectAll()
+
+	sp.wgt.TextAlign = TALIGN_LEFT
+	sp.wgt.Container.gui.ActiveWidget = sp.wgt
+}
+
+//finishEdit applies the typed value, the text which is not a number is rejected
+func (sp *spinner) finishEdit(cancel bool) {
+	sp.editing = false
+	sp.wgt.TextAlign = TALIGN_CENTER
+	sp.wgt.textOffset = 0 //the edit scrolls the text to the caret
+
+	if !cancel {
+		if v, err := sp.parse(sp.text); err == nil {
+			sp.change(v)
+		}
+	}
+	sp.wgt.Text = sp.format()
+}
+
+//buttons returns rects of the - and + buttons in the right padding
+func (sp *spinner) buttons() (minus, plus Rect) {
+	r := sp.wgt.Layout.GetBackgroundRect()
+
+	plus = r
+	plus.TLX = r.BRX - spinnerButtonSize
+	plus.W = spinnerButtonSize
+
+	minus = plus
+	minus.TLX -= spinnerButtonSize
+	minus.BRX -= spinnerButtonSize
+
+	return
+}
+
+func (sp *spinner) drawButtons(minus, plus Rect) {
+	wgt := sp.wgt
+	gui := wgt.Container.gui
+	mouse := gui.Mouse
+
+	for _, btn := range []struct {
+		r    Rect
+		text string
+	}{
+		{minus, "-"},
+		{plus, "+"},
+	} {
+		style := DefaultBtnStyle
+		if wgt.IsHover() && btn.r.ContainsPoint(mouse.X, mouse.Y) {
+			style = DefaultBtnStyleHover
+		}
+
+		cmd := gui.GetLastCmd(wgt.Z + 1)
+		cmd.DrawFilledRect(btn.r, style.BackgroundColor, defaultTextureSampler, whitePixelUv)
+
+		if wgt.Font == nil {
+			continue
+		}
+
+		gui.drawTextCentered(wgt.Font, btn.r, style.TextColor, btn.text, wgt.Z+1)
+	}
+}
@@ -0,0 +1,54 @@
+package fizzgui
+
+import "testing"
+
+func TestSpinner(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+
+	value := 5
+	changes := 0
+	wgt := c.NewSpinner(&value, 0, 10, 1, "%d ms", func(*Widget) { changes++ })
+	sp := wgt.ConstructorData.(*spinner)
+	g.Construct()
+	g.Construct()
+
+	minus, plus := sp.buttons()
+	y := plus.TLY - plus.H/2
+	clickPoint(g, host, plus.TLX+plus.W/2, y)
+	if value != 6 || changes != 1 {
+		t.Fatal("plus button sets", value, changes)
+	}
+
+	//the quick clicks on the button are not the double click starting the edit
+	waitClicks(g)
+	clickPoint(g, host, minus.TLX+minus.W/2, y)
+	clickPoint(g, host, minus.TLX+minus.W/2, y)
+	if value != 4 || sp.editing {
+		t.Fatal("minus button sets", value, sp.editing)
+	}
+
+	//the drag is limited by max
+	r := wgt.Layout.GetBackgroundRect()
+	waitClicks(g)
+	drag(g, host, r.TLX+50, y, r.TLX+500, y)
+	if value != 10 || wgt.Text != "10 ms" {
+		t.Fatal("drag sets", value, wgt.Text)
+	}
+
+	//the edit starts from the number without the format
+	waitClicks(g)
+	clickPoint(g, host, r.TLX+50, y)
+	clickPoint(g, host, r.TLX+50, y)
+	if !sp.editing || g.ActiveWidget != wgt || sp.text != "10" {
+		t.Fatalf("double click does not edit the number, text %q", sp.text)
+	}
+
+	host.TypeString("0000000000000000000000000000000000000000007")
+	g.Construct()
+	host.TypeKey(KeyEnter, 0)
+	g.Construct()
+	if value != 7 || wgt.Text != "7 ms" || wgt.textOffset != 0 {
+		t.Fatal("edit sets", value, wgt.Text, wgt.textOffset)
+	}
+}
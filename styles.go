@@ -56,9 +56,12 @@ var (
 	TextColor         = mgl32.Vec4{0.8, 0.8, 0.8, 1}
 	TextColorSelected = mgl32.Vec4{0.9, 0.9, 0.9, 1}
 	TextColorHiglight = mgl32.Vec4{0.17, 0.4, 0.63, 1}
+	TextColorDimmed   = mgl32.Vec4{0.8, 0.8, 0.8, 0.4}
 
 	BorderColor         = mgl32.Vec4{0.15, 0.15, 0.15, 1}
 	BorderColorHiglight = mgl32.Vec4{0.17, 0.4, 0.63, 1}
+	BorderColorInvalid  = mgl32.Vec4{0.7, 0.2, 0.2, 1}
+	BorderColorFocus    = mgl32.Vec4{0.45, 0.7, 0.95, 1}
 
 	BGColorImage      = mgl32.Vec4{0.9, 0.9, 0.9, 1}
 	BGColorImageHover = mgl32.Vec4{1, 1, 1, 1}
@@ -73,11 +76,31 @@ var (
 	DefaultBtnStyleHover  Style
 	DefaultBtnStyleActive Style
 
-	DefaultInputStyle       Style
-	DefaultInputStyleActive Style
+	DefaultInputStyle            Style
+	DefaultInputStyleActive      Style
+	DefaultInputStyleInvalid     Style
+	DefaultInputStylePlaceholder Style
+
+	DefaultTextSelectionStyle Style
 
 	DefaultDaDItemStyle      Style
 	DefaultDaDItemStyleHover Style
+
+	DefaultScrollBarStyle        Style
+	DefaultScrollThumbStyle      Style
+	DefaultScrollThumbStyleHover Style
+
+	DefaultTitleBarStyle      Style
+	DefaultTitleBtnStyleHover Style
+
+	DefaultComboPopupStyle     Style
+	DefaultComboItemStyleHover Style
+
+	DefaultSliderFillStyle       Style
+	DefaultSliderThumbStyle      Style
+	DefaultSliderThumbStyleHover Style
+
+	DefaultFocusRingStyle Style
 )
 
 func initDefaultStyles() {
@@ -91,7 +114,27 @@ func initDefaultStyles() {
 
 	DefaultInputStyle = NewStyle(TextColor, BGColor, n, 0)
 	DefaultInputStyleActive = NewStyle(TextColorSelected, BGColorHover, BorderColorHiglight, 2)
+	DefaultInputStyleInvalid = NewStyle(TextColorSelected, BGColorHover, BorderColorInvalid, 2)
+	DefaultInputStylePlaceholder = NewStyle(TextColorDimmed, n, n, 0)
+
+	DefaultTextSelectionStyle = NewStyle(TextColorSelected, BGColorHighlight, n, 0)
 
 	DefaultDaDItemStyle = NewStyle(n, BGColorImage, n, 0)
 	DefaultDaDItemStyleHover = NewStyle(n, BGColorImageHover, n, 0)
+
+	DefaultScrollBarStyle = NewStyle(n, BGColorBtn, n, 0)
+	DefaultScrollThumbStyle = NewStyle(n, BGColorSelected, n, 0)
+	DefaultScrollThumbStyleHover = NewStyle(n, BGColorHighlight, n, 0)
+
+	DefaultTitleBarStyle = NewStyle(TextColorSelected, BGColorHighlight, n, 0)
+	DefaultTitleBtnStyleHover = NewStyle(TextColorSelected, BGColorHover, n, 0)
+
+	DefaultComboPopupStyle = NewStyle(n, BGColorBtn, BorderColorHiglight, 1)
+	DefaultComboItemStyleHover = NewStyle(TextColorSelected, BGColorHighlight, n, 0)
+
+	DefaultSliderFillStyle = NewStyle(n, BGColorHighlight, n, 0)
+	DefaultSliderThumbStyle = NewStyle(n, TextColor, n, 0)
+	DefaultSliderThumbStyleHover = NewStyle(n, BGColorImageHover, n, 0)
+
+	DefaultFocusRingStyle = NewStyle(n, n, BorderColorFocus, 2)
 }
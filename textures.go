@@ -2,11 +2,11 @@ package fizzgui
 
 import (
 	"image"
+	"image/draw"
 	_ "image/png"
 	"os"
 
 	"github.com/go-gl/mathgl/mgl32"
-	"github.com/tbogdala/fizzle"
 	"github.com/tbogdala/fizzle/graphicsprovider"
 )
 
@@ -16,25 +16,19 @@ type TexturePack struct {
 }
 
 func NewTexturePack(img string) (*TexturePack, error) {
-	f, err := os.Open(img)
-	if err != nil {
-		return nil, err
-	}
-
-	info, _, err := image.DecodeConfig(f)
-	if err != nil {
-		return nil, err
-	}
+	return defaultGUI.NewTexturePack(img)
+}
 
-	tex, err := fizzle.LoadImageToTexture(img)
+func (g *GUI) NewTexturePack(img string) (*TexturePack, error) {
+	rgba, err := loadImage(img)
 	if err != nil {
 		return nil, err
 	}
 
 	tp := &TexturePack{
-		Tex:    tex,
-		Width:  float32(info.Width),
-		Height: float32(info.Height),
+		Tex:    g.renderer.NewTexture(rgba, true),
+		Width:  float32(rgba.Rect.Dx()),
+		Height: float32(rgba.Rect.Dy()),
 	}
 
 	return tp, nil
@@ -67,9 +61,40 @@ func (tp *TexturePack) NewChunk(x0, y0, x1, y1 float32) *Texture {
 }
 
 func NewTextureImg(img string) (*Texture, error) {
-	tex, err := fizzle.LoadImageToTexture(img)
+	return defaultGUI.NewTextureImg(img)
+}
+
+func (g *GUI) NewTextureImg(img string) (*Texture, error) {
+	rgba, err := loadImage(img)
 	if err != nil {
 		return nil, err
 	}
-	return &Texture{tex, imagePixelUv}, nil
+	return &Texture{g.renderer.NewTexture(rgba, true), imagePixelUv}, nil
+}
+
+//loadImage decodes image file to not premultiplied pixels turned upside down,
+//so the top of the image is at v=1 as the texture chunks expect
+func loadImage(img string) (*image.RGBA, error) {
+	f, err := os.Open(img)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+
+	b := src.Bounds()
+	nrgba := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(nrgba, nrgba.Rect, src, b.Min, draw.Src)
+
+	flipped := image.NewRGBA(nrgba.Rect)
+	for y := 0; y < b.Dy(); y++ {
+		row := nrgba.Pix[y*nrgba.Stride : y*nrgba.Stride+b.Dx()*4]
+		copy(flipped.Pix[(b.Dy()-1-y)*flipped.Stride:], row)
+	}
+
+	return flipped, nil
 }
@@ -2,8 +2,8 @@ package fizzgui
 
 import (
 	"log"
+	"math"
 
-	"github.com/go-gl/glfw/v3.2/glfw"
 	"github.com/go-gl/mathgl/mgl32"
 )
 
@@ -53,8 +53,29 @@ type Widget struct {
 	OnActive   Callback
 	OnKeyEnter Callback
 
+	//mouse callbacks, OnClick is called for each button, OnRightClick for the right one only
+	OnClick       MouseCallback
+	OnRightClick  MouseCallback
+	OnDoubleClick MouseCallback
+	OnMouseDown   MouseCallback
+	OnMouseUp     MouseCallback
+	OnHoverEnter  MouseCallback
+	OnHoverLeave  MouseCallback
+	OnScroll      MouseCallback
+
+	//Focusable widgets are focused by Tab and Shift+Tab, TabIndex overrides the order of the containers
+	Focusable bool
+	TabIndex  int
+	OnFocus   Callback
+	OnBlur    Callback
+
 	ConstructorData interface{}
 	Constructor     WidgetConstructor
+	onDestroy       func() //releases the resources of the widget outside of its container
+	frame           uint64 //last frame the widget is constructed in
+
+	scrollable bool //widget scrolls its content by the mouse wheel instead of the container
+	textOffset int  //number of the first runes of the text skipped by the rendering
 
 	UserData interface{}
 }
@@ -62,6 +83,11 @@ type Widget struct {
 func (wgt *Widget) Destroy() {
 	wgt.Hidden = true
 	wgt.destroy = true
+
+	if wgt.onDestroy != nil {
+		wgt.onDestroy()
+		wgt.onDestroy = nil
+	}
 }
 
 func (wgt *Widget) SetStyles(normal, hover, active Style, tex *Texture) {
@@ -72,14 +98,14 @@ func (wgt *Widget) SetStyles(normal, hover, active Style, tex *Texture) {
 }
 
 func (wgt *Widget) IsHover() bool {
-	if HoverWidget == wgt {
+	if wgt.Container.gui.HoverWidget == wgt {
 		return true
 	}
 	return false
 }
 
 func (wgt *Widget) IsActive() bool {
-	if ActiveWidget == wgt {
+	if wgt.Container.gui.ActiveWidget == wgt {
 		if wgt.State < STATE_ACTIVE {
 			wgt.State = STATE_ACTIVE
 		}
@@ -90,26 +116,29 @@ func (wgt *Widget) IsActive() bool {
 }
 
 func (wgt *Widget) IsClick() (click bool, onWidget bool) {
-	ma := Mouse.GetButtonAction(0)
+	return wgt.IsButtonClick(0)
+}
+
+//IsButtonClick reports whether the mouse button is released in the current frame and whether it is over the widget
+func (wgt *Widget) IsButtonClick(button int) (click bool, onWidget bool) {
+	mouse := wgt.Container.gui.Mouse
+	ma := mouse.GetButtonAction(button)
 	// ma := wgt.Window.Owner.GetMouseButtonAction(0)
 	// mx, my := wgt.Window.Owner.GetMousePosition()
 	if ma == MouseClick || ma == MouseDoubleClick {
 		click = true
-		if wgt.Layout.ContainsPoint(Mouse.X, Mouse.Y) {
-			onWidget = true
-		}
+		onWidget = wgt.IsHover()
 	}
 	return
 }
 
 func (wgt *Widget) IsMouseDown() (down bool, onWidget bool) {
-	ma := Mouse.GetButtonAction(0)
+	mouse := wgt.Container.gui.Mouse
+	ma := mouse.GetButtonAction(0)
 
 	if ma == MouseDown {
 		down = true
-		if wgt.Layout.ContainsPoint(Mouse.X, Mouse.Y) {
-			onWidget = true
-		}
+		onWidget = wgt.IsHover()
 	}
 
 	return
@@ -121,6 +150,12 @@ func (wgt *Widget) draw(cursor *Cursor) (w, h float32) {
 	l := wgt.Layout
 	l.Update()
 
+	//fixed widgets are moved with the scrolled content
+	if c := wgt.Container; l.PositionFixed && l.parent == c.Layout {
+		l.X -= c.ScrollOffsetX
+		l.Y += c.ScrollOffset
+	}
+
 	var wt, ht float32
 	if wgt.Font != nil {
 		if wgt.Text != "" {
@@ -143,10 +178,11 @@ func (wgt *Widget) draw(cursor *Cursor) (w, h float32) {
 		style = wgt.StyleActive
 	}
 
-	if ActiveWidget == wgt {
+	if wgt.Container.gui.ActiveWidget == wgt {
 		wgt.Z++
 	}
 
+	wgt.frame = wgt.Container.gui.frame
 	if wgt.Constructor != nil {
 		if cstyle := wgt.Constructor(); cstyle.exist {
 			style = cstyle
@@ -166,6 +202,7 @@ func (wgt *Widget) draw(cursor *Cursor) (w, h float32) {
 	case style.exist && style.BackgroundColor[3] > 0:
 		wgt.renderBackground(r, style)
 	}
+	wgt.renderFocusRing(r)
 
 	if wgt.Font != nil && wgt.Text != "" {
 		wgt.renderText(l.GetContentRect(), style, wt, ht)
@@ -188,25 +225,25 @@ func (wgt *Widget) renderText(r Rect, style Style, w, h float32) {
 	var rt *RenderData
 	switch wgt.TextAlign {
 	case TALIGN_LEFT:
-		rt = wgt.Font.CreateTextAdv(wgt.Layout.GetTextPosLeft(h), style.TextColor, maxWidth, -1, -1, wgt.Text)
+		rt = wgt.Font.CreateTextAdv(wgt.Layout.GetTextPosLeft(h), style.TextColor, maxWidth, wgt.textOffset, -1, wgt.Text)
 	case TALIGN_CENTER:
-		rt = wgt.Font.CreateTextAdv(wgt.Layout.GetTextPosCenter(w, h), style.TextColor, maxWidth, -1, -1, wgt.Text)
+		rt = wgt.Font.CreateTextAdv(wgt.Layout.GetTextPosCenter(w, h), style.TextColor, maxWidth, wgt.textOffset, -1, wgt.Text)
 	case TALIGN_RIGHT:
-		rt = wgt.Font.CreateTextAdv(wgt.Layout.GetTextPosRight(w, h), style.TextColor, maxWidth, -1, -1, wgt.Text)
+		rt = wgt.Font.CreateTextAdv(wgt.Layout.GetTextPosRight(w, h), style.TextColor, maxWidth, wgt.textOffset, -1, wgt.Text)
 	}
 
-	cmd := GetLastCmd(wgt.Z)
+	cmd := wgt.Container.gui.GetLastCmd(wgt.Z)
 	cmd.texture = wgt.Font.Texture
 	cmd.AddFaces(rt.ComboBuffer, rt.IndexBuffer, rt.Faces)
 }
 
 func (wgt *Widget) renderTexture(r Rect, style Style, tc *Texture) {
-	cmd := GetLastCmd(wgt.Z)
+	cmd := wgt.Container.gui.GetLastCmd(wgt.Z)
 	cmd.DrawFilledRect(r, style.BackgroundColor, tc.Tex, tc.Offset)
 }
 
 func (wgt *Widget) renderBackground(r Rect, style Style) {
-	cmd := GetLastCmd(wgt.Z)
+	cmd := wgt.Container.gui.GetLastCmd(wgt.Z)
 	cmd.DrawFilledRect(r, style.BackgroundColor, defaultTextureSampler, whitePixelUv)
 
 	if style.BorderWidth > 0 && style.BorderColor[3] > 0 {
@@ -241,7 +278,7 @@ func renderBorder(cmd *cmdList, r Rect, style Style) {
 func (c *Container) NewText(text string) *Widget {
 	wgt := &Widget{
 		Text:      text,
-		Font:      GetFont(c.FontName),
+		Font:      c.gui.GetFont(c.FontName),
 		Style:     DefaultTextStyle,
 		Container: c,
 		Layout:    NewLayoutZero(c.Layout),
@@ -278,13 +315,14 @@ func (c *Container) NewButton(text string, f Callback) *Widget {
 	wgt := &Widget{
 		Text:        text,
 		TextAlign:   TALIGN_CENTER,
-		Font:        GetFont(c.FontName),
+		Font:        c.gui.GetFont(c.FontName),
 		Style:       DefaultBtnStyle,
 		StyleHover:  DefaultBtnStyleHover,
 		StyleActive: DefaultBtnStyleActive,
 		Container:   c,
 		Layout:      NewLayoutZero(c.Layout),
 		OnActive:    f,
+		Focusable:   true,
 	}
 	wgt.Constructor = wgt.buttonConstructor
 
@@ -293,147 +331,89 @@ func (c *Container) NewButton(text string, f Callback) *Widget {
 }
 
 func (wgt *Widget) buttonConstructor() (style Style) {
+	gui := wgt.Container.gui
 
-	if wgt.OnActive != nil {
-		click, onWidget := wgt.IsClick()
-		if click && onWidget {
+	click, onWidget := wgt.IsClick()
+	if click && onWidget {
+		gui.ActiveWidget = wgt
+	} else if wgt.IsFocused() && gui.Mouse.JustPressed(0) && !wgt.IsHover() {
+		gui.ActiveWidget = nil
+	}
+
+	//the focused button is not drawn pressed
+	if wgt.IsFocused() {
+		style = wgt.Style
+		if wgt.IsHover() && wgt.StyleHover.exist {
+			style = wgt.StyleHover
+		}
+	}
+
+	if click && onWidget || wgt.keyActivated() {
+		if wgt.OnActive != nil {
 			wgt.OnActive(wgt)
-			style = wgt.StyleActive
 		}
+		style = wgt.StyleActive
 	}
 
 	return
 }
 
-// NewInput creates an editbox control that changes the value string.
-func (c *Container) NewInput(id string, text *string, f Callback) *Widget {
+func (c *Container) NewCheckbox(value *bool, f Callback) *Widget {
 	wgt := &Widget{
-		ID:          id,
-		Text:        *text,
-		Font:        GetFont(c.FontName),
-		Style:       DefaultInputStyle,
-		StyleActive: DefaultInputStyleActive,
+		Style:       DefaultBtnStyle,
+		StyleHover:  DefaultBtnStyleHover,
+		StyleActive: DefaultBtnStyleActive,
 		Container:   c,
-		Layout:      NewLayout("", "", "100%", "28px", c.Layout),
-		OnKeyEnter:  f,
+		Layout:      NewLayout("", "", "28px", "28px", c.Layout),
+		OnActive:    f,
+		Focusable:   true,
 	}
-	wgt.ConstructorData = &input{value: text, runes: []rune(*text)}
-	wgt.Constructor = wgt.inputConstructor
+	wgt.ConstructorData = &checkbox{value: value}
+	wgt.Constructor = wgt.checkboxConstructor
 
 	c.addWidget(wgt)
-	return wgt
-}
 
-type input struct {
-	value       *string
-	runes       []rune
-	cursor      int
-	cursorTimer float32
+	return wgt
 }
 
-func (wgt *Widget) inputConstructor() (style Style) {
-
-	click, onWidget := wgt.IsClick()
-	if click {
-		if onWidget {
-			ActiveWidget = wgt
-			return
-		} else if ActiveWidget == wgt {
-			ActiveWidget = nil
-			return
-		}
-	}
-
-	if ActiveWidget == nil {
-		Keys.DisableListening()
-		return
-	}
-
-	if ActiveWidget != wgt {
-		return
-	}
-
-	inp := wgt.ConstructorData.(*input)
-
-	// grab the key events
-	for _, k := range Keys.GetKeys() {
-
-		switch k.KeyCode {
-		case glfw.KeyRight:
-			if inp.cursor < len(inp.runes) {
-				inp.cursor++
-			}
-		case glfw.KeyLeft:
-			if inp.cursor > 0 {
-				inp.cursor--
-			}
-		case glfw.KeyBackspace:
-			if inp.cursor > 0 {
-				inp.runes = append(inp.runes[:inp.cursor-1], inp.runes[inp.cursor:]...)
-				inp.cursor--
-			}
-		case glfw.KeyDelete:
-			if inp.cursor < len(inp.runes) {
-				inp.runes = append(inp.runes[:inp.cursor], inp.runes[inp.cursor+1:]...)
-			}
-		case glfw.KeyEnter, glfw.KeyKPEnter:
-			if wgt.OnKeyEnter != nil {
-				wgt.OnKeyEnter(wgt)
-			}
-			ActiveWidget = nil
-		case glfw.KeyEscape:
-			ActiveWidget = nil
-		case glfw.KeyEnd:
-			inp.cursor = len(inp.runes)
-		case glfw.KeyHome:
-			inp.cursor = 0
-		case glfw.KeyV:
-			if k.Ctrl {
-				str, _ := window.GetClipboardString()
-				inp.runes = append(inp.runes[:inp.cursor],
-					append([]rune(str), inp.runes[inp.cursor:]...)...)
-			}
-		}
-	}
-
-	runes := Keys.GetRunes()
-	inp.runes = append(inp.runes[:inp.cursor],
-		append(runes, inp.runes[inp.cursor:]...)...)
-	inp.cursor += len(runes)
+//checkboxSize is the size of the box drawn before the label
+const checkboxSize = 16
 
-	*inp.value = string(inp.runes)
-	wgt.Text = *inp.value
-
-	inp.cursorTimer += dt
-	if inp.cursorTimer < 0.6 {
-		//render text cursor vertical line
-
-		lenText := wgt.Font.OffsetForIndex(*inp.value, inp.cursor)
+//toggle switch sizes and the time of the knob move
+const (
+	toggleWidth  = 32
+	toggleHeight = 16
+	toggleTime   = 0.15
+)
 
-		r := wgt.Layout.GetContentRect()
-		r.TLX += lenText + 1
-		r.BRX = r.TLX + 2
+//NewCheckboxLabel creates checkbox with the label, the click on the label changes the value too
+func (c *Container) NewCheckboxLabel(value *bool, label string, f Callback) *Widget {
+	return c.newLabelledCheckbox(value, label, &checkbox{value: value, markW: checkboxSize}, f)
+}
 
-		cmd := GetLastCmd(wgt.Z + 1)
-		cmd.DrawFilledRect(r, wgt.StyleActive.TextColor, defaultTextureSampler, whitePixelUv)
+//NewToggle creates toggle switch with the label, the knob is moved to the right when the value is true
+func (c *Container) NewToggle(value *bool, label string, f Callback) *Widget {
+	chk := &checkbox{value: value, markW: toggleWidth, toggle: true}
+	if *value {
+		chk.knob = 1
 	}
-	if inp.cursorTimer > 1 {
-		inp.cursorTimer = 0
-	}
-
-	return wgt.StyleActive
+	return c.newLabelledCheckbox(value, label, chk, f)
 }
 
-func (c *Container) NewCheckbox(value *bool, f Callback) *Widget {
+func (c *Container) newLabelledCheckbox(value *bool, label string, chk *checkbox, f Callback) *Widget {
 	wgt := &Widget{
-		Style:       DefaultBtnStyle,
-		StyleHover:  DefaultBtnStyleHover,
-		StyleActive: DefaultBtnStyleActive,
-		Container:   c,
-		Layout:      NewLayout("", "", "28px", "28px", c.Layout),
-		OnActive:    f,
+		Text:      label,
+		Font:      c.gui.GetFont(c.FontName),
+		Style:     DefaultTextStyle,
+		Container: c,
+		Layout:    NewLayoutZero(c.Layout),
+		OnActive:  f,
+		Focusable: true,
 	}
-	wgt.ConstructorData = &checkbox{value: value}
+	//the mark is in the left padding between the equal gaps
+	wgt.Layout.Padding.L += chk.markW + wgt.Layout.Padding.L
+
+	wgt.ConstructorData = chk
 	wgt.Constructor = wgt.checkboxConstructor
 
 	c.addWidget(wgt)
@@ -443,38 +423,135 @@ func (c *Container) NewCheckbox(value *bool, f Callback) *Widget {
 
 type checkbox struct {
 	value *bool
+
+	markW  float32 //width of the mark before the label, 0 if the checkbox has no label
+	toggle bool
+	knob   float32 //animated position of the toggle knob from 0 to 1
 }
 
 func (wgt *Widget) checkboxConstructor() (style Style) {
 
 	chk := wgt.ConstructorData.(*checkbox)
 
+	gui := wgt.Container.gui
+
 	click, onWidget := wgt.IsClick()
 	if click && onWidget {
-		*chk.value = !*chk.value
-		log.Println(*chk.value)
+		gui.ActiveWidget = wgt
+	} else if wgt.IsFocused() && gui.Mouse.JustPressed(0) && !wgt.IsHover() {
+		gui.ActiveWidget = nil
+	}
 
-		if *chk.value {
-			wgt.State = STATE_CHECKED
-		} else {
-			wgt.State = STATE_NORMAL
+	if wgt.IsFocused() {
+		style = wgt.Style
+		if wgt.IsHover() && wgt.StyleHover.exist {
+			style = wgt.StyleHover
 		}
+	}
+
+	if click && onWidget || wgt.keyActivated() {
+		*chk.value = !*chk.value
 
 		if wgt.OnActive != nil {
 			wgt.OnActive(wgt)
 		}
 	}
 
-	if wgt.State == STATE_CHECKED && wgt.StyleActive.exist {
+	if *chk.value {
+		wgt.State = STATE_CHECKED
+	} else {
+		wgt.State = STATE_NORMAL
+	}
+
+	switch {
+	case chk.toggle:
+		chk.drawToggle(wgt)
+	case chk.markW > 0:
+		chk.drawBox(wgt)
+	case wgt.State == STATE_CHECKED && wgt.StyleActive.exist:
 		r := wgt.Layout.GetContentRect()
 
-		cmd := GetLastCmd(wgt.Zorder + 1)
+		cmd := wgt.Container.gui.GetLastCmd(wgt.Z + 1)
 		cmd.DrawFilledRect(r, wgt.StyleActive.TextColor, defaultTextureSampler, whitePixelUv)
 	}
 
 	return
 }
 
+//markRect returns rect of the mark in the left padding of the labelled checkbox
+func (chk *checkbox) markRect(wgt *Widget, h float32) Rect {
+	bg := wgt.Layout.GetBackgroundRect()
+	x := bg.TLX + (wgt.Layout.Padding.L-chk.markW)/2
+	y := bg.TLY - bg.H/2 + h/2
+
+	return Rect{TLX: x, TLY: y, BRX: x + chk.markW, BRY: y - h, W: chk.markW, H: h}
+}
+
+func (chk *checkbox) drawBox(wgt *Widget) {
+	r := chk.markRect(wgt, checkboxSize)
+
+	style := DefaultInputStyle
+	if wgt.IsHover() {
+		style = DefaultInputStyleActive
+	}
+
+	cmd := wgt.Container.gui.GetLastCmd(wgt.Z + 1)
+	cmd.DrawFilledRect(r, style.BackgroundColor, defaultTextureSampler, whitePixelUv)
+
+	if wgt.State == STATE_CHECKED {
+		const inset = 4
+		r.TLX += inset
+		r.TLY -= inset
+		r.BRX -= inset
+		r.BRY += inset
+		r.W -= inset * 2
+		r.H -= inset * 2
+		cmd.DrawFilledRect(r, style.TextColor, defaultTextureSampler, whitePixelUv)
+	}
+}
+
+//drawToggle draws the rounded track with the knob moving to the value
+func (chk *checkbox) drawToggle(wgt *Widget) {
+	gui := wgt.Container.gui
+
+	target := float32(0)
+	if wgt.State == STATE_CHECKED {
+		target = 1
+	}
+	if step := gui.dt / toggleTime; chk.knob < target {
+		chk.knob = clampFloat(chk.knob+step, 0, target)
+	} else {
+		chk.knob = clampFloat(chk.knob-step, target, 1)
+	}
+
+	r := chk.markRect(wgt, toggleHeight)
+	radius := r.H / 2
+
+	color := BGColor
+	if chk.knob > 0.5 {
+		color = BGColorHighlight
+	}
+	if wgt.IsHover() {
+		color = color.Add(mgl32.Vec4{0.1, 0.1, 0.1, 0})
+	}
+
+	cmd := gui.GetLastCmd(wgt.Z + 1)
+	left := mgl32.Vec2{r.TLX + radius, r.TLY - radius}
+	right := mgl32.Vec2{r.BRX - radius, r.TLY - radius}
+	cmd.DrawFilledCircle(left, radius, color)
+	cmd.DrawFilledCircle(right, radius, color)
+
+	middle := r
+	middle.TLX = left[0]
+	middle.BRX = right[0]
+	middle.W = middle.BRX - middle.TLX
+	cmd.DrawFilledRect(middle, color, defaultTextureSampler, whitePixelUv)
+
+	knob := left
+	knob[0] += (right[0] - left[0]) * chk.knob
+	cmd.DrawFilledCircle(knob, radius-2, TextColorSelected)
+}
+
 func (c *Container) NewProgressBar(value *float32, min, max float32, f Callback) *Widget {
 	wgt := &Widget{
 		Style:       DefaultBtnStyle,
@@ -516,12 +593,288 @@ func (wgt *Widget) progressbarConstructor() (style Style) {
 
 	r.BRX = r.TLX + r.W*percent
 
-	cmd := GetLastCmd(wgt.Zorder + 1)
+	cmd := wgt.Container.gui.GetLastCmd(wgt.Zorder + 1)
 	cmd.DrawFilledRect(r, wgt.StyleActive.TextColor, defaultTextureSampler, whitePixelUv)
 
 	return
 }
 
+//
+// Combobox ===================================
+//
+
+//comboPopupZorder lifts the popup list above the container of the combobox
+const comboPopupZorder = 100
+
+//comboArrowSize is the width of the arrow at the right side of the combobox
+const comboArrowSize = 10
+
+type combobox struct {
+	wgt      *Widget
+	options  []string
+	selected *int
+
+	popup     *Container
+	items     []*comboItem
+	highlight int
+	follow    bool //scroll the popup to the highlighted item
+
+	mouseX, mouseY float32
+	mouseMoved     bool
+}
+
+//comboItem is an option in the popup list
+type comboItem struct {
+	*Widget
+
+	combo *combobox
+	index int
+}
+
+//NewCombobox creates dropdown list of options, selected is index of the chosen option,
+//callback is called when the user changes the selection
+func (c *Container) NewCombobox(id string, options []string, selected *int, f Callback) *Widget {
+	wgt := &Widget{
+		ID:          id,
+		Font:        c.gui.GetFont(c.FontName),
+		Style:       DefaultBtnStyle,
+		StyleHover:  DefaultBtnStyleHover,
+		StyleActive: DefaultBtnStyleActive,
+		Container:   c,
+		Layout:      NewLayout("", "", "100%", "28px", c.Layout),
+		OnActive:    f,
+		Focusable:   true,
+	}
+	wgt.Layout.Padding.R += comboArrowSize
+
+	popup := c.gui.NewContainer(id+"_popup", "0", "0", "0", "0")
+	popup.Hidden = true
+	popup.IsScrollable = true
+	popup.ShowScrollBar = true
+	popup.FontName = c.FontName
+	popup.Style = DefaultComboPopupStyle
+	popup.Layout.Margin = Offset{}
+	popup.Layout.Padding = Offset{1, 1, 1, 1}
+
+	cb := &combobox{wgt: wgt, options: options, selected: selected, popup: popup}
+	popup.owner = wgt
+	popup.onClose = cb.close
+	for i, opt := range options {
+		item := &comboItem{popup.NewText(opt), cb, i}
+		item.Layout.SetWidth("100%")
+		item.Constructor = item.constructor
+		cb.items = append(cb.items, item)
+	}
+
+	wgt.ConstructorData = cb
+	wgt.Constructor = wgt.comboboxConstructor
+	wgt.onDestroy = func() {
+		cb.close()
+		c.gui.DelContainer(popup)
+	}
+
+	c.addWidget(wgt)
+	return wgt
+}
+
+func (wgt *Widget) comboboxConstructor() (style Style) {
+	gui := wgt.Container.gui
+	cb := wgt.ConstructorData.(*combobox)
+
+	if *cb.selected >= 0 && *cb.selected < len(cb.options) {
+		wgt.Text = cb.options[*cb.selected]
+	}
+
+	mouse := gui.Mouse
+	cb.mouseMoved = mouse.X != cb.mouseX || mouse.Y != cb.mouseY
+	cb.mouseX, cb.mouseY = mouse.X, mouse.Y
+
+	if wgt.Hidden {
+		cb.close()
+		return
+	}
+
+	//arrow in the right padding
+	r := wgt.Layout.GetContentRect()
+	cx := r.BRX + comboArrowSize/2
+	cy := r.TLY - r.H/2
+	cmd := gui.GetLastCmd(wgt.Z + 1)
+	cmd.DrawFilledTriangle(
+		mgl32.Vec2{cx - comboArrowSize/2, cy + comboArrowSize/4},
+		mgl32.Vec2{cx, cy - comboArrowSize/4},
+		mgl32.Vec2{cx + comboArrowSize/2, cy + comboArrowSize/4},
+		wgt.Style.TextColor)
+
+	click, onWidget := wgt.IsClick()
+	if click && onWidget {
+		if cb.popup.Hidden {
+			cb.open()
+		} else {
+			cb.close()
+		}
+	}
+
+	if cb.popup.Hidden {
+		if !wgt.IsFocused() {
+			return
+		}
+
+		//the focused combobox is opened by the keyboard and keeps the focus until the click outside
+		if gui.Mouse.JustPressed(0) && !wgt.IsHover() {
+			gui.ActiveWidget = nil
+			return
+		}
+		for _, k := range gui.Keys.GetKeys() {
+			switch k.KeyCode {
+			case KeyEnter, KeyKPEnter, KeySpace:
+				cb.open()
+			}
+		}
+		switch {
+		case !cb.popup.Hidden:
+			return wgt.StyleActive
+		case wgt.IsHover():
+			return wgt.StyleHover
+		}
+		return wgt.Style
+	}
+
+	//press outside of the combobox and the popup or activation of other widget closes it
+	if gui.ActiveWidget != wgt || (mouse.JustPressed(0) && !wgt.IsHover() && gui.HoverContainer != cb.popup) {
+		cb.close()
+		return
+	}
+
+	for _, k := range gui.Keys.GetKeys() {
+		switch k.KeyCode {
+		case KeyEscape:
+			cb.close()
+			gui.ActiveWidget = wgt
+			return
+		case KeyUp:
+			gui.Keys.use(KeyUp)
+			if cb.highlight > 0 {
+				cb.highlight--
+				cb.follow = true
+			}
+		case KeyDown:
+			gui.Keys.use(KeyDown)
+			if cb.highlight < len(cb.options)-1 {
+				cb.highlight++
+				cb.follow = true
+			}
+		case KeyEnter, KeyKPEnter:
+			cb.choose(cb.highlight)
+			gui.ActiveWidget = wgt
+			return
+		}
+	}
+
+	//item positions are known after the popup was constructed once
+	if cb.follow && cb.popup.contentH > 0 && cb.highlight >= 0 && cb.highlight < len(cb.items) {
+		cb.popup.ScrollTo(cb.items[cb.highlight].Widget)
+		cb.follow = false
+	}
+
+	cb.place()
+
+	return wgt.StyleActive
+}
+
+func (cb *combobox) open() {
+	gui := cb.wgt.Container.gui
+
+	cb.highlight = *cb.selected
+	cb.follow = true
+
+	cb.popup.Hidden = false
+	//the popup stays above the owner when its Zorder is near the limit
+	z := int(cb.wgt.Container.root().Zorder) + comboPopupZorder
+	if z > math.MaxUint8 {
+		z = math.MaxUint8
+	}
+	cb.popup.Zorder = uint8(z)
+	cb.popup.BringToFront()
+	cb.place()
+
+	gui.ActiveWidget = cb.wgt
+}
+
+func (cb *combobox) close() {
+	cb.popup.Hidden = true
+
+	if gui := cb.wgt.Container.gui; gui.ActiveWidget == cb.wgt {
+		gui.ActiveWidget = nil
+	}
+}
+
+func (cb *combobox) choose(i int) {
+	if i < 0 || i >= len(cb.options) {
+		return
+	}
+
+	changed := *cb.selected != i
+	*cb.selected = i
+	cb.wgt.Text = cb.options[i]
+	cb.close()
+
+	if changed && cb.wgt.OnActive != nil {
+		cb.wgt.OnActive(cb.wgt)
+	}
+}
+
+//place puts the popup under the combobox or above it if there is more space,
+//the popup is shortened to the space and scrolled
+func (cb *combobox) place() {
+	r := cb.wgt.Layout.GetBackgroundRect()
+	wnd := cb.wgt.Container.gui.wndLayout.GetContentRect()
+	l := cb.popup.Layout
+
+	var h float32
+	if font := cb.wgt.Font; font != nil {
+		_, th, _ := font.GetRenderSize("`j*}")
+		for _, item := range cb.items {
+			_, ih := item.Layout.AddOffsets(0, th)
+			h += ih
+		}
+	}
+	_, h = l.AddOffsets(0, h)
+
+	below := r.BRY - wnd.BRY
+	above := wnd.TLY - r.TLY
+
+	y := r.BRY
+	if h > below && above > below {
+		if h > above {
+			h = above
+		}
+		y = r.TLY + h
+	} else if h > below {
+		h = below
+	}
+
+	l.Resize(r.W, h)
+	l.MoveTo(r.TLX, y)
+}
+
+func (item *comboItem) constructor() (style Style) {
+	cb := item.combo
+
+	if cb.mouseMoved && item.IsHover() {
+		cb.highlight = item.index
+	}
+
+	if click, onWidget := item.IsClick(); click && onWidget {
+		cb.choose(item.index)
+	}
+
+	if cb.highlight == item.index {
+		style = DefaultComboItemStyleHover
+	}
+
+	return
+}
+
 //
 // DragAndDrop ===================================
 //
@@ -539,10 +892,17 @@ type DADGroup struct {
 	activeSlot *DADSlot
 }
 
-//NewDragAndDropGroup create new drag and drop group
+//NewDragAndDropGroup create new drag and drop group in the default gui
 func NewDragAndDropGroup(id string) *DADGroup {
-	c := NewContainer(id, "", "", "100%", "100%")
+	return defaultGUI.NewDragAndDropGroup(id)
+}
+
+//NewDragAndDropGroup create new drag and drop group
+func (g *GUI) NewDragAndDropGroup(id string) *DADGroup {
+	c := g.NewContainer(id, "", "", "100%", "100%")
 	c.Style.BackgroundColor[3] = 0
+	c.PassThrough = true
+	c.Zorder = 1 //dragged items are above the other containers
 	group := &DADGroup{c, id, nil, nil, nil, nil}
 
 	return group
@@ -579,7 +939,7 @@ func (group *DADGroup) NewItem(id, img string, data interface{}) *DADItem {
 	wgt.Layout.VAlign = VAlignMiddle
 
 	var err error
-	wgt.Texture, err = NewTextureImg(img)
+	wgt.Texture, err = c.gui.NewTextureImg(img)
 	if err != nil {
 		log.Println(err)
 	}
@@ -613,11 +973,12 @@ func (item *DADItem) constructor() (style Style) {
 		}
 
 		if item.Group.activeItem == item {
-			ActiveWidget = item.Widget
+			gui := item.Container.gui
+			gui.ActiveWidget = item.Widget
 			item.Widget.Zorder++
 			//move item with mouse
-			item.Layout.X = Mouse.X
-			item.Layout.Y = Mouse.Y
+			item.Layout.X = gui.Mouse.X
+			item.Layout.Y = gui.Mouse.Y
 		}
 	}
 
@@ -693,7 +1054,7 @@ func (slot *DADSlot) constructor() (style Style) {
 
 		style = slot.StyleHover
 
-		if slot.Layout.ContainsPoint(Mouse.X, Mouse.Y) {
+		if mouse := slot.Container.gui.Mouse; slot.Layout.ContainsPoint(mouse.X, mouse.Y) {
 			style = slot.StyleActive
 			slot.Group.activeSlot = slot
 		} else if slot.Group.activeSlot == slot {
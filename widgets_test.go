@@ -0,0 +1,108 @@
+package fizzgui
+
+import "testing"
+
+func TestComboboxPopup(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+	c.Zorder = 200
+
+	selected := 0
+	cb := c.NewCombobox("cb", []string{"one", "two"}, &selected, nil)
+	g.Construct()
+	if len(g.containers) != 2 {
+		t.Fatal("popup container is not created")
+	}
+
+	click(g, host, cb)
+	popup := cb.ConstructorData.(*combobox).popup
+	if popup.Hidden || popup.Zorder < c.Zorder {
+		t.Fatal("popup is not opened above the owner", popup.Hidden, popup.Zorder)
+	}
+
+	cb.Destroy()
+	g.Construct()
+	if len(g.containers) != 1 || g.containers[0] != c {
+		t.Fatal("popup is left after the combobox is destroyed")
+	}
+}
+
+func TestComboboxPopupOwner(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+
+	selected := 0
+	cb := c.NewCombobox("cb", []string{"one", "two"}, &selected, nil)
+	popup := cb.ConstructorData.(*combobox).popup
+
+	click(g, host, cb)
+	if popup.Hidden {
+		t.Fatal("popup is not opened")
+	}
+
+	c.Hidden = true
+	g.Construct()
+	if !popup.Hidden || g.ActiveWidget == cb {
+		t.Fatal("popup is open while the owner container is hidden")
+	}
+
+	c.Hidden = false
+	click(g, host, cb)
+	if popup.Hidden {
+		t.Fatal("popup is not opened again")
+	}
+
+	g.DelContainer(c)
+	g.Construct()
+	if len(g.containers) != 0 {
+		t.Fatal("popup is left after the owner container is removed", len(g.containers))
+	}
+}
+
+func TestRadioArrows(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+
+	selected := 0
+	group := c.NewRadioGroup(&selected, []string{"a", "b", "c", "d"}, nil)
+	g.Construct()
+
+	g.Focus(group.Item(0))
+	for i, want := range []int{1, 2, 1} {
+		key := KeyDown
+		if i == 2 {
+			key = KeyUp
+		}
+		host.TypeKey(key, 0)
+		g.Construct()
+
+		if selected != want || g.ActiveWidget != group.Item(want) {
+			t.Fatalf("arrow %d selects the item %d, want %d", i, selected, want)
+		}
+	}
+}
+
+func TestCheckboxLabelClick(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	c := g.NewContainer("c", "10px", "10px", "300px", "200px")
+
+	checked, on := false, true
+	changes := 0
+	chk := c.NewCheckboxLabel(&checked, "checkbox label", func(*Widget) { changes++ })
+	c.NewRow()
+	toggle := c.NewToggle(&on, "toggle", nil)
+	g.Construct()
+	g.Construct()
+
+	//the label toggles the value as the box
+	r := chk.Layout.GetContentRect()
+	clickPoint(g, host, r.TLX+r.W/4, r.TLY-r.H/2)
+	if !checked || changes != 1 {
+		t.Fatal("click on the label sets", checked, changes)
+	}
+
+	click(g, host, toggle)
+	if on {
+		t.Fatal("click on the toggle does not switch it off")
+	}
+}
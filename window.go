@@ -0,0 +1,270 @@
+package fizzgui
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+//window drag modes, edges are combined for the corners
+const (
+	windowMove uint8 = 1 << iota
+	windowEdgeL
+	windowEdgeT
+	windowEdgeR
+	windowEdgeB
+)
+
+//resizeBorder is width of the window edges grabbed for resizing
+const resizeBorder = 5
+
+//NewWindow creates container in the window mode in the default gui
+//x,y,w,h is string size ex: "80%", "200px"...
+func NewWindow(id, title string, x, y, w, h string) *Container {
+	return defaultGUI.NewWindow(id, title, x, y, w, h)
+}
+
+//NewWindow creates movable and resizable container with the title bar and close and collapse buttons
+//x,y,w,h is string size ex: "80%", "200px"...
+func (g *GUI) NewWindow(id, title string, x, y, w, h string) *Container {
+	c := g.NewContainer(id, x, y, w, h)
+	c.Window = true
+	c.Title = title
+	c.Movable = true
+	c.Resizable = true
+	c.Closable = true
+	c.Collapsible = true
+
+	return c
+}
+
+//titleBarHeight returns height of the title bar, it is 0 if the container is not a window
+func (c *Container) titleBarHeight() float32 {
+	if !c.Window {
+		return 0
+	}
+
+	_, h, _ := c.gui.GetFont(c.FontName).GetRenderSize("`j*}")
+	return h + c.Layout.Padding.T + c.Layout.Padding.B
+}
+
+//titleBarRect returns rect of the title bar at the top of the container background
+func (c *Container) titleBarRect() Rect {
+	r := c.Layout.GetBackgroundRect()
+	r.BRY = r.TLY - c.titleBarHeight()
+	r.H = r.TLY - r.BRY
+	return r
+}
+
+//titleButtons returns rects of the close and collapse buttons, the rect is empty if there is no such button
+func (c *Container) titleButtons() (closeBtn, collapseBtn Rect) {
+	r := c.titleBarRect()
+
+	btn := r
+	btn.TLX = r.BRX - r.H
+	btn.W = r.H
+
+	if c.Closable {
+		closeBtn = btn
+		btn.TLX -= r.H
+		btn.BRX -= r.H
+	}
+	if c.Collapsible {
+		collapseBtn = btn
+	}
+
+	return
+}
+
+//windowMinSize returns minimal size of the window layout
+func (c *Container) windowMinSize() (w, h float32) {
+	l := c.Layout
+	titleH := c.titleBarHeight()
+
+	w, h = l.w.min, l.h.min
+	if w < titleH*3 {
+		w = titleH * 3
+	}
+	if minH := l.Margin.T + titleH + l.Padding.T + l.Padding.B + l.Margin.B; h < minH {
+		h = minH
+	}
+
+	return
+}
+
+//windowGrab returns the drag mode started by the mouse press at x,y
+func (c *Container) windowGrab(x, y float32) uint8 {
+	r := c.Layout.GetBackgroundRect()
+	if !r.ContainsPoint(x, y) {
+		return 0
+	}
+
+	var edges uint8
+	if c.Resizable && !c.Collapsed {
+		if x < r.TLX+resizeBorder {
+			edges |= windowEdgeL
+		}
+		if x > r.BRX-resizeBorder {
+			edges |= windowEdgeR
+		}
+		if y > r.TLY-resizeBorder {
+			edges |= windowEdgeT
+		}
+		if y < r.BRY+resizeBorder {
+			edges |= windowEdgeB
+		}
+	}
+	if edges != 0 {
+		return edges
+	}
+
+	closeBtn, collapseBtn := c.titleButtons()
+	if c.Movable && c.titleBarRect().ContainsPoint(x, y) && !closeBtn.ContainsPoint(x, y) && !collapseBtn.ContainsPoint(x, y) {
+		return windowMove
+	}
+
+	return 0
+}
+
+//updateWindow handles the title bar buttons, moving and resizing of the window,
+//result is written back to the layout sizes
+func (c *Container) updateWindow() {
+	l := c.Layout
+	mouse := c.gui.Mouse
+	hover := c.gui.HoverContainer == c
+
+	action := mouse.GetButtonAction(0)
+	if action != MouseDown {
+		c.winDrag = 0
+	}
+
+	if hover && (action == MouseClick || action == MouseDoubleClick) {
+		px, py := mouse.GetButtonDownPosition(0)
+		closeBtn, collapseBtn := c.titleButtons()
+
+		switch {
+		case closeBtn.ContainsPoint(px, py) && closeBtn.ContainsPoint(mouse.X, mouse.Y):
+			c.Hidden = true
+			if c.OnClose != nil {
+				c.OnClose(c)
+			}
+		case collapseBtn.ContainsPoint(px, py) && collapseBtn.ContainsPoint(mouse.X, mouse.Y):
+			c.Collapsed = !c.Collapsed
+		}
+	}
+
+	if hover && c.winDrag == 0 && mouse.JustPressed(0) {
+		c.winDrag = c.windowGrab(mouse.X, mouse.Y)
+		c.winDragX, c.winDragY = mouse.X, mouse.Y
+		c.winDragRect = Rect{TLX: l.X, TLY: l.Y, W: l.W, H: l.H}
+	}
+
+	if c.winDrag != 0 {
+		dx := mouse.X - c.winDragX
+		dy := mouse.Y - c.winDragY
+
+		s := c.winDragRect
+		x, y, w, h := s.TLX, s.TLY, s.W, s.H
+		minW, minH := c.windowMinSize()
+
+		if c.winDrag == windowMove {
+			x += dx
+			y += dy
+		}
+		if c.winDrag&windowEdgeL != 0 {
+			w = s.W - dx
+		}
+		if c.winDrag&windowEdgeR != 0 {
+			w = s.W + dx
+		}
+		if c.winDrag&windowEdgeT != 0 {
+			h = s.H + dy
+		}
+		if c.winDrag&windowEdgeB != 0 {
+			h = s.H - dy
+		}
+
+		if w < minW {
+			w = minW
+		}
+		if h < minH {
+			h = minH
+		}
+
+		//left and top edges move the position, the opposite edges stay in place
+		if c.winDrag&windowEdgeL != 0 {
+			x = s.TLX + s.W - w
+		}
+		if c.winDrag&windowEdgeT != 0 {
+			y = s.TLY - s.H + h
+		}
+
+		//size goes first, the position depends on it by the align
+		if w != l.W || h != l.H {
+			l.Resize(w, h)
+		}
+		l.MoveTo(x, y)
+	}
+
+	titleH := c.titleBarHeight()
+	l.reserved.T = titleH
+	if c.Collapsed {
+		l.H = l.Margin.T + titleH + l.Margin.B
+	}
+}
+
+func (c *Container) drawTitleBar() {
+	r := c.titleBarRect()
+	mouse := c.gui.Mouse
+
+	cmd := c.gui.GetLastCmd(c.Zorder)
+	cmd.DrawFilledRect(r, c.TitleBarStyle.BackgroundColor, defaultTextureSampler, whitePixelUv)
+
+	closeBtn, collapseBtn := c.titleButtons()
+
+	title := r
+	if c.Closable || c.Collapsible {
+		title.BRX = closeBtn.TLX
+		if c.Collapsible {
+			title.BRX = collapseBtn.TLX
+		}
+		title.W = title.BRX - title.TLX
+	}
+	c.drawTitleText(title, c.Title, false)
+
+	for _, btn := range []struct {
+		show bool
+		r    Rect
+		text string
+	}{
+		{c.Closable, closeBtn, "x"},
+		{c.Collapsible && !c.Collapsed, collapseBtn, "-"},
+		{c.Collapsible && c.Collapsed, collapseBtn, "+"},
+	} {
+		if !btn.show {
+			continue
+		}
+
+		if c.gui.HoverContainer == c && btn.r.ContainsPoint(mouse.X, mouse.Y) {
+			cmd := c.gui.GetLastCmd(c.Zorder)
+			cmd.DrawFilledRect(btn.r, c.TitleBtnStyleHover.BackgroundColor, defaultTextureSampler, whitePixelUv)
+		}
+		c.drawTitleText(btn.r, btn.text, true)
+	}
+}
+
+//drawTitleText draws text in the middle of the rect height clipped by it
+func (c *Container) drawTitleText(r Rect, text string, center bool) {
+	if text == "" {
+		return
+	}
+	font := c.gui.GetFont(c.FontName)
+
+	c.gui.pushClip(r)
+	if center {
+		c.gui.drawTextCentered(font, r, c.TitleBarStyle.TextColor, text, c.Zorder)
+	} else {
+		_, h, _ := font.GetRenderSize(text)
+		pos := mgl32.Vec2{r.TLX + c.Layout.Padding.L, r.TLY - r.H/2 + h/2}
+		c.gui.drawText(font, pos, c.TitleBarStyle.TextColor, text, c.Zorder)
+	}
+	c.gui.popClip()
+}
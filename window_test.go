@@ -0,0 +1,30 @@
+package fizzgui
+
+import "testing"
+
+func TestWindowTitleDrag(t *testing.T) {
+	g, host, _ := newTestGUI(t, 400, 300)
+	w := g.NewWindow("w", "Tools", "10%", "20px", "200px", "150px")
+	w.NewButton("button", nil)
+	g.Construct()
+	g.Construct()
+
+	//the window is moved by 50 to the right and 30 down
+	tb := w.titleBarRect()
+	drag(g, host, tb.TLX+20, tb.TLY-tb.H/2, tb.TLX+70, tb.TLY-tb.H/2-30)
+
+	l := w.Layout
+	if l.x.value != 90.0/400 || !l.x.percent {
+		t.Fatal("x is not written back in percents", l.x)
+	}
+	if l.y.value != 50 || l.y.percent {
+		t.Fatal("y is not written back in pixels", l.y)
+	}
+
+	//the position is kept by the layout update
+	host.Resize(800, 600)
+	g.Construct()
+	if l.X != 180 {
+		t.Fatal("window is not placed by the written position", l.X)
+	}
+}